@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// readMaskRoutes are the routes whose requests take a read mask.
+var readMaskRoutes = map[string]bool{
+	"/v1/list-races":  true,
+	"/v1/get-race":    true,
+	"/v1/list-events": true,
+}
+
+// withFields sets the read mask of a request from its fields query parameter,
+// a comma separated list of the fields to return, such as
+// ?fields=id,name,advertised_start_time. The routes take their request from
+// the body alone, so the parameter is merged into it, replacing any read mask
+// already there.
+//
+// The services clear every field a read mask leaves out, but the gateway
+// renders every field of a response, set or not. A request with a read mask,
+// however given, is served by masked instead, whose marshaler renders only
+// the fields set, so that fields left out cost nothing. As in any proto3
+// JSON, a field named by the mask is left out too while it holds its zero
+// value.
+func withFields(next, masked http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !readMaskRoutes[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(b))
+
+		body := make(map[string]json.RawMessage)
+		if len(bytes.TrimSpace(b)) != 0 {
+			if err := json.Unmarshal(b, &body); err != nil {
+				// The gateway reports the malformed body.
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		if fields := r.URL.Query().Get("fields"); fields != "" {
+			// Field masks are written in JSON as a string of lowerCamelCase
+			// paths.
+			paths := strings.Split(fields, ",")
+			for i, path := range paths {
+				paths[i] = lowerCamelCase(strings.TrimSpace(path))
+			}
+
+			delete(body, "read_mask")
+			body["readMask"], _ = json.Marshal(strings.Join(paths, ","))
+
+			b, err = json.Marshal(body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(b))
+			r.ContentLength = int64(len(b))
+		}
+
+		if hasReadMask(body) {
+			masked.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// hasReadMask reports whether a request body sets a read mask.
+func hasReadMask(body map[string]json.RawMessage) bool {
+	for _, key := range []string{"readMask", "read_mask"} {
+		var mask string
+		if json.Unmarshal(body[key], &mask) == nil && mask != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lowerCamelCase converts a snake_case path to lowerCamelCase, leaving one
+// already in lowerCamelCase as it is.
+func lowerCamelCase(path string) string {
+	words := strings.Split(path, "_")
+	for i := 1; i < len(words); i++ {
+		if words[i] != "" {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+
+	return strings.Join(words, "")
+}
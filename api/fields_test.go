@@ -0,0 +1,69 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFields(t *testing.T) {
+	handler := newTestHandler(t)
+
+	for _, tc := range []struct {
+		name string
+		path string
+		body string
+		want string
+	}{
+		{
+			// Fields the mask names are left out while zero, as unset.
+			name: "fields parameter",
+			path: "/v1/get-race?fields=id,meeting_id,visible",
+			body: `{"raceId": "1"}`,
+			want: `{"race":{"id":"1","meetingId":"5"}}`,
+		},
+		{
+			name: "read mask in the body",
+			path: "/v1/list-races",
+			body: `{"read_mask": "id,name"}`,
+			want: `{"races":[{"id":"1","name":"Flemington Cup"},{"id":"2"}]}`,
+		},
+		{
+			// The parameter replaces any mask in the body.
+			name: "fields parameter replacing read mask",
+			path: "/v1/list-races?fields=id",
+			body: `{"readMask": "id,name"}`,
+			want: `{"races":[{"id":"1"},{"id":"2"}]}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body)))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, body %s, want %d", w.Code, w.Body, http.StatusOK)
+			}
+			if body := w.Body.String(); body != tc.want {
+				t.Errorf("body = %s, want %s", body, tc.want)
+			}
+		})
+	}
+}
+
+func TestFieldsWithoutMask(t *testing.T) {
+	handler := newTestHandler(t)
+
+	// Without a read mask, every field is rendered.
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/get-race", strings.NewReader(`{"raceId": "1"}`)))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s, want %d", w.Code, w.Body, http.StatusOK)
+	}
+	for _, field := range []string{`"id":"1"`, `"meetingId":"5"`, `"visible":false`, `"status":"STATUS_UNSPECIFIED"`} {
+		if body := w.Body.String(); !strings.Contains(body, field) {
+			t.Errorf("body = %s, want it to hold %s", body, field)
+		}
+	}
+}
@@ -5,12 +5,22 @@ import (
 	"flag"
 	"log"
 	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
 
 	"git.neds.sh/matty/entain/api/proto/sports"
 
 	"git.neds.sh/matty/entain/api/proto/racing"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	// The error details attached by the services are registered so that the
+	// gateway can render them in error bodies, rather than failing to and
+	// responding 500.
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails"
 )
 
 var (
@@ -32,26 +42,122 @@ func run() error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	mux := runtime.NewServeMux()
-	if err := racing.RegisterRacingHandlerFromEndpoint(
-		ctx,
-		mux,
-		*grpcEndpointRacing,
-		[]grpc.DialOption{grpc.WithInsecure()},
-	); err != nil {
+	handler, err := newHandler(ctx, *grpcEndpointRacing, *grpcEndpointEvents)
+	if err != nil {
 		return err
 	}
 
-	if err := sports.RegisterEventsHandlerFromEndpoint(
-		ctx,
-		mux,
-		*grpcEndpointEvents,
-		[]grpc.DialOption{grpc.WithInsecure()},
-	); err != nil {
-		return err
+	log.Printf("API server listening on: %s\n", *apiEndpoint)
+
+	return http.ListenAndServe(*apiEndpoint, handler)
+}
+
+// newHandler returns the handler serving the API, proxying requests to the
+// racing and events services at the given gRPC endpoints until ctx is done.
+func newHandler(ctx context.Context, racingEndpoint, eventsEndpoint string) (http.Handler, error) {
+	racingConn, err := dial(ctx, racingEndpoint)
+	if err != nil {
+		return nil, err
 	}
 
-	log.Printf("API server listening on: %s\n", *apiEndpoint)
+	eventsConn, err := dial(ctx, eventsEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	mux, err := newMux(ctx, racingConn, eventsConn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Requests with a read mask are served by a mux rendering only the
+	// fields set, which are those the services leave after applying it.
+	masked, err := newMux(ctx, racingConn, eventsConn, runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.HTTPBodyMarshaler{
+		Marshaler: &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: false},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		},
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return withFields(mux, masked), nil
+}
+
+// newMux returns a gateway mux serving the racing and events services over
+// the given connections.
+func newMux(ctx context.Context, racingConn, eventsConn *grpc.ClientConn, opts ...runtime.ServeMuxOption) (*runtime.ServeMux, error) {
+	mux := runtime.NewServeMux(append([]runtime.ServeMuxOption{
+		runtime.WithForwardResponseOption(setETag),
+		runtime.WithIncomingHeaderMatcher(matchHeader),
+	}, opts...)...)
+
+	if err := racing.RegisterRacingHandler(ctx, mux, racingConn); err != nil {
+		return nil, err
+	}
+
+	if err := sports.RegisterEventsHandler(ctx, mux, eventsConn); err != nil {
+		return nil, err
+	}
+
+	return mux, nil
+}
+
+// dial connects to a service at endpoint, closing the connection once ctx
+// is done.
+func dial(ctx context.Context, endpoint string) (*grpc.ClientConn, error) {
+	conn, err := grpc.Dial(endpoint, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := conn.Close(); err != nil {
+			log.Printf("failed closing connection to %s: %s\n", endpoint, err)
+		}
+	}()
+
+	return conn, nil
+}
+
+// auditHeaders are the headers callers name themselves and give their reason
+// for a change in, forwarded to the services as metadata of the same name.
+var auditHeaders = map[string]bool{
+	"X-Actor":  true,
+	"X-Reason": true,
+}
+
+// matchHeader forwards the audit headers, along with those the gateway
+// forwards by default.
+func matchHeader(key string) (string, bool) {
+	key = textproto.CanonicalMIMEHeaderKey(key)
+	if auditHeaders[key] {
+		return strings.ToLower(key), true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// setETag surfaces the etag of a single race returned through the gateway as
+// an HTTP ETag header.
+func setETag(ctx context.Context, w http.ResponseWriter, resp proto.Message) error {
+	var etag string
+
+	switch m := resp.(type) {
+	case *racing.WatchRacesResponse:
+		// Streamed responses share one set of headers.
+		return nil
+	case *racing.Race:
+		etag = m.GetEtag()
+	case interface{ GetRace() *racing.Race }:
+		etag = m.GetRace().GetEtag()
+	}
+
+	if etag != "" {
+		w.Header().Set("ETag", strconv.Quote(etag))
+	}
 
-	return http.ListenAndServe(*apiEndpoint, mux)
+	return nil
 }
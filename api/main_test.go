@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"git.neds.sh/matty/entain/api/proto/racing"
+)
+
+// racingServer fails requests as the racing service does: invalid input with
+// the field at fault, and missing races as not found.
+type racingServer struct {
+	racing.UnimplementedRacingServer
+}
+
+func (racingServer) CreateRace(ctx context.Context, in *racing.CreateRaceRequest) (*racing.Race, error) {
+	st, err := status.New(codes.InvalidArgument, "invalid argument: race.name: must not be empty").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "race.name", Description: "must not be empty"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, st.Err()
+}
+
+// GetRaceById holds only race 1, cut down to the fields of the read mask as
+// the racing service does.
+func (racingServer) GetRaceById(ctx context.Context, in *racing.GetRaceByIdRequest) (*racing.GetRaceByIdResponse, error) {
+	if in.RaceId != 1 {
+		return nil, status.Errorf(codes.NotFound, "not found: race %d does not exist", in.RaceId)
+	}
+
+	race := &racing.Race{Id: 1, MeetingId: 5, Name: "Flemington Cup"}
+	applyReadMask(race, in.GetReadMask())
+
+	return &racing.GetRaceByIdResponse{Race: race}, nil
+}
+
+// ListRaces returns two races, cut down to the fields of the read mask as the
+// racing service does.
+func (racingServer) ListRaces(ctx context.Context, in *racing.ListRacesRequest) (*racing.ListRacesResponse, error) {
+	races := []*racing.Race{{Id: 1, MeetingId: 5, Name: "Flemington Cup"}, {Id: 2, MeetingId: 5}}
+	for _, race := range races {
+		applyReadMask(race, in.GetReadMask())
+	}
+
+	return &racing.ListRacesResponse{Races: races}, nil
+}
+
+// applyReadMask clears every field of m not named by mask, unless mask is
+// empty.
+func applyReadMask(m proto.Message, mask *field_mask.FieldMask) {
+	if len(mask.GetPaths()) == 0 {
+		return
+	}
+
+	keep := make(map[string]bool)
+	for _, path := range mask.GetPaths() {
+		keep[path] = true
+	}
+
+	msg := m.ProtoReflect()
+
+	var clear []protoreflect.FieldDescriptor
+	msg.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if !keep[string(fd.Name())] {
+			clear = append(clear, fd)
+		}
+		return true
+	})
+
+	for _, fd := range clear {
+		msg.Clear(fd)
+	}
+}
+
+// newTestHandler serves the API in front of racingServer, until the test ends.
+func newTestHandler(t *testing.T) http.Handler {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+
+	grpcServer := grpc.NewServer()
+	racing.RegisterRacingServer(grpcServer, racingServer{})
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// The events service is never called, so nothing need listen for it.
+	handler, err := newHandler(ctx, lis.Addr().String(), "127.0.0.1:1")
+	if err != nil {
+		t.Fatalf("newHandler: %v", err)
+	}
+
+	return handler
+}
+
+func TestErrorMapping(t *testing.T) {
+	handler := newTestHandler(t)
+
+	for _, tc := range []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantCode   codes.Code
+		wantFields []string
+	}{
+		{
+			name:       "invalid argument",
+			path:       "/v1/create-race",
+			body:       `{"race": {"meetingId": "1"}}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codes.InvalidArgument,
+			wantFields: []string{"race.name"},
+		},
+		{
+			name:       "not found",
+			path:       "/v1/get-race",
+			body:       `{"raceId": "99"}`,
+			wantStatus: http.StatusNotFound,
+			wantCode:   codes.NotFound,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body)))
+
+			if w.Code != tc.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+
+			b, err := io.ReadAll(w.Body)
+			if err != nil {
+				t.Fatalf("reading body: %v", err)
+			}
+
+			var st spb.Status
+			if err := protojson.Unmarshal(b, &st); err != nil {
+				t.Fatalf("body %s is not a status: %v", b, err)
+			}
+			if codes.Code(st.Code) != tc.wantCode {
+				t.Errorf("code = %s, want %s", codes.Code(st.Code), tc.wantCode)
+			}
+
+			var fields []string
+			for _, detail := range st.Details {
+				var br errdetails.BadRequest
+				if err := detail.UnmarshalTo(&br); err != nil {
+					t.Fatalf("detail %v is not a BadRequest: %v", detail, err)
+				}
+				for _, violation := range br.FieldViolations {
+					fields = append(fields, violation.Field)
+				}
+			}
+			if strings.Join(fields, ",") != strings.Join(tc.wantFields, ",") {
+				t.Errorf("field violations = %v, want %v", fields, tc.wantFields)
+			}
+		})
+	}
+}
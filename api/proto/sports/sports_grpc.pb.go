@@ -20,6 +20,13 @@ const _ = grpc.SupportPackageIsVersion7
 type EventsClient interface {
 	// ListEvents will return a collection of all Events.
 	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error)
+	// SearchEvents returns the events whose names match a query, most
+	// relevant first.
+	SearchEvents(ctx context.Context, in *SearchEventsRequest, opts ...grpc.CallOption) (*SearchEventsResponse, error)
+	// ListEventHistory returns the changes made to an event, oldest first,
+	// including any made before it was deleted. Events are only written by
+	// the seed command, which records who ran it, and archived by retention.
+	ListEventHistory(ctx context.Context, in *ListEventHistoryRequest, opts ...grpc.CallOption) (*ListEventHistoryResponse, error)
 }
 
 type eventsClient struct {
@@ -39,12 +46,37 @@ func (c *eventsClient) ListEvents(ctx context.Context, in *ListEventsRequest, op
 	return out, nil
 }
 
+func (c *eventsClient) SearchEvents(ctx context.Context, in *SearchEventsRequest, opts ...grpc.CallOption) (*SearchEventsResponse, error) {
+	out := new(SearchEventsResponse)
+	err := c.cc.Invoke(ctx, "/sports.Events/SearchEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventsClient) ListEventHistory(ctx context.Context, in *ListEventHistoryRequest, opts ...grpc.CallOption) (*ListEventHistoryResponse, error) {
+	out := new(ListEventHistoryResponse)
+	err := c.cc.Invoke(ctx, "/sports.Events/ListEventHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EventsServer is the server API for Events service.
 // All implementations must embed UnimplementedEventsServer
 // for forward compatibility
 type EventsServer interface {
 	// ListEvents will return a collection of all Events.
 	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
+	// SearchEvents returns the events whose names match a query, most
+	// relevant first.
+	SearchEvents(context.Context, *SearchEventsRequest) (*SearchEventsResponse, error)
+	// ListEventHistory returns the changes made to an event, oldest first,
+	// including any made before it was deleted. Events are only written by
+	// the seed command, which records who ran it, and archived by retention.
+	ListEventHistory(context.Context, *ListEventHistoryRequest) (*ListEventHistoryResponse, error)
 	mustEmbedUnimplementedEventsServer()
 }
 
@@ -55,6 +87,12 @@ type UnimplementedEventsServer struct {
 func (UnimplementedEventsServer) ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListEvents not implemented")
 }
+func (UnimplementedEventsServer) SearchEvents(context.Context, *SearchEventsRequest) (*SearchEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchEvents not implemented")
+}
+func (UnimplementedEventsServer) ListEventHistory(context.Context, *ListEventHistoryRequest) (*ListEventHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEventHistory not implemented")
+}
 func (UnimplementedEventsServer) mustEmbedUnimplementedEventsServer() {}
 
 // UnsafeEventsServer may be embedded to opt out of forward compatibility for this service.
@@ -86,6 +124,42 @@ func _Events_ListEvents_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Events_SearchEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventsServer).SearchEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sports.Events/SearchEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventsServer).SearchEvents(ctx, req.(*SearchEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Events_ListEventHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEventHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventsServer).ListEventHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sports.Events/ListEventHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventsServer).ListEventHistory(ctx, req.(*ListEventHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Events_ServiceDesc is the grpc.ServiceDesc for Events service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -97,6 +171,14 @@ var Events_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListEvents",
 			Handler:    _Events_ListEvents_Handler,
 		},
+		{
+			MethodName: "SearchEvents",
+			Handler:    _Events_SearchEvents_Handler,
+		},
+		{
+			MethodName: "ListEventHistory",
+			Handler:    _Events_ListEventHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "sports/sports.proto",
@@ -0,0 +1,21 @@
+package db
+
+import "time"
+
+// Clock tells the time. Repositories derive time-dependent state, such as
+// race status, from a Clock rather than the system time, so that the time
+// can be fixed or replayed.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to a Clock.
+type ClockFunc func() time.Time
+
+// Now calls f.
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is a Clock telling the system time.
+var SystemClock Clock = ClockFunc(time.Now)
@@ -1,30 +1,342 @@
 package db
 
 import (
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/golang/protobuf/ptypes"
 	"syreclabs.com/go/faker"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
 )
 
-func (r *racesRepo) seed() error {
-	statement, err := r.db.Prepare(`CREATE TABLE IF NOT EXISTS races (id INTEGER PRIMARY KEY, meeting_id INTEGER, name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME)`)
-	if err == nil {
-		_, err = statement.Exec()
+// SeedOptions configures the dummy data Seed generates. The same options
+// always generate the same data.
+type SeedOptions struct {
+	// Seed seeds every random choice made, such as names and start times.
+	Seed int64
+	// Meetings is the number of meetings, held at each venue in turn.
+	Meetings int
+	// RacesPerMeeting is the number of races run at each meeting, numbered
+	// from 1.
+	RacesPerMeeting int
+	// MinRunners and MaxRunners bound the number of runners in each race.
+	MinRunners, MaxRunners int
+	// From is the first day meetings are held on, and Days the number of
+	// days they are spread across.
+	From time.Time
+	Days int
+	// BatchSize is the most rows inserted in a single transaction.
+	BatchSize int
+	// Reset deletes any existing data before seeding. Without it, seeding a
+	// database which already holds races, meetings or runners fails.
+	Reset bool
+	// Audit is who is seeding and why, recorded in the history of each race
+	// created or deleted. History is never reset.
+	Audit Audit
+}
+
+// SeedResult counts the rows Seed inserted.
+type SeedResult struct {
+	Meetings, Races, Runners int
+}
+
+// seededTables are the tables Seed requires be empty, or empties when
+// resetting, in an order that deletes races only after what refers to them.
+// Race history is kept, so races deleted by a reset appear deleted in it.
+var seededTables = []string{"price_history", "prices", "markets", "result_dividends", "result_placings", "results", "runners", "races", "meetings"}
+
+// venues are the venues meetings are seeded at, along with their country,
+// code of racing and IANA time zone.
+var venues = []struct {
+	name     string
+	country  string
+	category string
+	timeZone string
+}{
+	{"Flemington", "AU", "THOROUGHBRED", "Australia/Melbourne"},
+	{"Randwick", "AU", "THOROUGHBRED", "Australia/Sydney"},
+	{"Eagle Farm", "AU", "THOROUGHBRED", "Australia/Brisbane"},
+	{"Menangle", "AU", "HARNESS", "Australia/Sydney"},
+	{"Wentworth Park", "AU", "GREYHOUND", "Australia/Sydney"},
+	{"Ellerslie", "NZ", "THOROUGHBRED", "Pacific/Auckland"},
+	{"Addington", "NZ", "HARNESS", "Pacific/Auckland"},
+	{"Sha Tin", "HK", "THOROUGHBRED", "Asia/Hong_Kong"},
+	{"Cheltenham", "GB", "THOROUGHBRED", "Europe/London"},
+	{"Romford", "GB", "GREYHOUND", "Europe/London"},
+}
+
+// maxRunnersPerRace bounds the runners seeded per race, so that each race's
+// runners occupy their own fixed range of IDs.
+const maxRunnersPerRace = 20
+
+// Seed fills the racing database with dummy meetings, races and runners,
+// for test and example purposes, inserting them in transactions of at most
+// opts.BatchSize rows. Meetings are held on random days from opts.From, each
+// running its races from late morning, local to its venue, 20 to 40 minutes
+// apart.
+//
+// Names are generated by faker, whose shared random source Seed reseeds, so
+// nothing else may use faker while seeding.
+func Seed(db *sql.DB, opts SeedOptions) (*SeedResult, error) {
+	var invalid string
+	switch {
+	case opts.Meetings < 1:
+		invalid = "meetings must be positive"
+	case opts.RacesPerMeeting < 1:
+		invalid = "races per meeting must be positive"
+	case opts.MinRunners < 1:
+		invalid = "min runners must be positive"
+	case opts.MaxRunners < opts.MinRunners || opts.MaxRunners > maxRunnersPerRace:
+		invalid = fmt.Sprintf("max runners must be from min runners to %d", maxRunnersPerRace)
+	case opts.Days < 1:
+		invalid = "days must be positive"
+	case opts.BatchSize < 1:
+		invalid = "batch size must be positive"
+	}
+	if invalid != "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidArgument, invalid)
+	}
+
+	now := SystemClock.Now()
+	if err := prepareSeed(db, opts.Reset, opts.Audit, now); err != nil {
+		return nil, err
+	}
+
+	var (
+		rng    = rand.New(rand.NewSource(opts.Seed))
+		batch  = &seedBatch{db: db, size: opts.BatchSize}
+		result SeedResult
+		from   = time.Date(opts.From.Year(), opts.From.Month(), opts.From.Day(), 0, 0, 0, 0, time.UTC)
+	)
+	defer batch.rollback()
+
+	faker.Seed(opts.Seed)
+
+	for meetingId := 1; meetingId <= opts.Meetings; meetingId++ {
+		venue := venues[(meetingId-1)%len(venues)]
+		day := from.AddDate(0, 0, rng.Intn(opts.Days))
+
+		loc, err := loadLocation(venue.timeZone)
+		if err != nil {
+			return nil, err
+		}
+
+		err = batch.insert(`INSERT INTO meetings(id, name, country, date, category, status, time_zone) VALUES (?,?,?,?,?,?,?)`,
+			meetingId, venue.name, venue.country, day.Format(meetingDateLayout), venue.category, "SCHEDULED", venue.timeZone)
+		if err != nil {
+			return nil, err
+		}
+		result.Meetings++
+
+		// The first race starts between 10:00 and 14:00 at the venue.
+		start := time.Date(day.Year(), day.Month(), day.Day(), 10, 0, 0, 0, loc).Add(time.Duration(rng.Intn(49)) * 5 * time.Minute)
+
+		for number := 1; number <= opts.RacesPerMeeting; number++ {
+			raceId := (meetingId-1)*opts.RacesPerMeeting + number
+			if number > 1 {
+				start = start.Add(time.Duration(20+rng.Intn(21)) * time.Minute)
+			}
+
+			advertisedStart, err := ptypes.TimestampProto(start)
+			if err != nil {
+				return nil, err
+			}
+
+			race := &racing.Race{
+				Id:                  int64(raceId),
+				MeetingId:           int64(meetingId),
+				Name:                faker.Team().Name(),
+				Number:              int64(number),
+				Visible:             rng.Intn(2) == 1,
+				AdvertisedStartTime: advertisedStart,
+				Status:              racing.RaceStatus_OPEN,
+				Etag:                "1",
+				Category:            racing.RaceCategory(racing.RaceCategory_value[venue.category]),
+			}
+
+			err = batch.insert(`INSERT INTO races(id, meeting_id, name, number, visible, advertised_start_time, status, category) VALUES (?,?,?,?,?,?,?,?)`,
+				race.Id, race.MeetingId, race.Name, race.Number, race.Visible, start.UTC().Format(time.RFC3339), "OPEN", venue.category)
+			if err != nil {
+				return nil, err
+			}
+			result.Races++
+
+			change, err := newRaceChange(nil, race, opts.Audit, now)
+			if err != nil {
+				return nil, err
+			}
+			args, err := raceChangeArgs(change)
+			if err != nil {
+				return nil, err
+			}
+			if err := batch.insert(getRaceQueries()[racesHistoryInsert], args...); err != nil {
+				return nil, err
+			}
+
+			fieldSize := opts.MinRunners + rng.Intn(opts.MaxRunners-opts.MinRunners+1)
+			barriers := rng.Perm(fieldSize)
+
+			for runner := 1; runner <= fieldSize; runner++ {
+				err := batch.insert(`INSERT INTO runners(id, race_id, number, name, barrier, weight) VALUES (?,?,?,?,?,?)`,
+					(raceId-1)*maxRunnersPerRace+runner,
+					raceId,
+					runner,
+					titleCase(faker.Commerce().Color()+" "+faker.Hacker().Noun()),
+					barriers[runner-1]+1,
+					54+float64(rng.Intn(61))/10,
+				)
+				if err != nil {
+					return nil, err
+				}
+				result.Runners++
+			}
+		}
+	}
+
+	if err := batch.commit(); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// titleCase capitalises the first letter of each word of a name.
+func titleCase(name string) string {
+	words := strings.Split(name, " ")
+	for i, word := range words {
+		if r, size := utf8.DecodeRuneInString(word); size > 0 {
+			words[i] = string(unicode.ToUpper(r)) + word[size:]
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// prepareSeed checks the seeded tables are empty, or empties them if reset
+// is set, so that seeding always produces the same rows. Each race deleted is
+// recorded in its history, attributed to audit.
+func prepareSeed(db *sql.DB, reset bool, audit Audit, now time.Time) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if reset {
+		rows, err := tx.Query(getRaceQueries()[racesList])
+		if err != nil {
+			return err
+		}
+		races, err := scanRaces(rows, time.Time{}, raceColumns)
+		rows.Close()
+		if err != nil {
+			return err
+		}
+
+		for _, race := range races {
+			if err := recordRaceChange(tx, race, nil, audit, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, table := range seededTables {
+		if reset {
+			if _, err := tx.Exec(`DELETE FROM ` + table); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var exists bool
+		if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM ` + table + `)`).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("%w: table %s is not empty; reset to replace its rows", ErrFailedPrecondition, table)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// seedBatch inserts rows in transactions of at most size rows, preparing
+// each distinct statement once per transaction.
+type seedBatch struct {
+	db   *sql.DB
+	size int
+
+	tx         *sql.Tx
+	statements map[string]*sql.Stmt
+	rows       int
+}
+
+// insert executes query with args, beginning a transaction if none is open
+// and committing it once it holds size rows.
+func (b *seedBatch) insert(query string, args ...interface{}) error {
+	if b.tx == nil {
+		tx, err := b.db.Begin()
+		if err != nil {
+			return err
+		}
+		b.tx, b.statements, b.rows = tx, make(map[string]*sql.Stmt), 0
 	}
 
-	for i := 1; i <= 100; i++ {
-		statement, err = r.db.Prepare(`INSERT OR IGNORE INTO races(id, meeting_id, name, number, visible, advertised_start_time) VALUES (?,?,?,?,?,?)`)
-		if err == nil {
-			_, err = statement.Exec(
-				i,
-				faker.Number().Between(1, 10),
-				faker.Team().Name(),
-				faker.Number().Between(1, 12),
-				faker.Number().Between(0, 1),
-				faker.Time().Between(time.Now().AddDate(0, 0, -1), time.Now().AddDate(0, 0, 2)).Format(time.RFC3339),
-			)
+	statement, ok := b.statements[query]
+	if !ok {
+		var err error
+		if statement, err = b.tx.Prepare(query); err != nil {
+			return err
 		}
+		b.statements[query] = statement
+	}
+
+	if _, err := statement.Exec(args...); err != nil {
+		return err
 	}
 
+	b.rows++
+	if b.rows == b.size {
+		return b.commit()
+	}
+
+	return nil
+}
+
+// commit commits the open transaction, if there is one.
+func (b *seedBatch) commit() error {
+	if b.tx == nil {
+		return nil
+	}
+
+	b.closeStatements()
+	err := b.tx.Commit()
+	b.tx = nil
+
 	return err
 }
+
+// rollback rolls back the open transaction, if there is one. Batches
+// already committed are kept.
+func (b *seedBatch) rollback() {
+	if b.tx == nil {
+		return
+	}
+
+	b.closeStatements()
+	b.tx.Rollback()
+	b.tx = nil
+}
+
+func (b *seedBatch) closeStatements() {
+	for _, statement := range b.statements {
+		statement.Close()
+	}
+	b.statements = nil
+}
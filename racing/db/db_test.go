@@ -0,0 +1,132 @@
+package db_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"git.neds.sh/matty/entain/racing/db"
+)
+
+func TestSeedIsReproducible(t *testing.T) {
+	opts := db.SeedOptions{
+		Seed:            42,
+		Meetings:        4,
+		RacesPerMeeting: 3,
+		MinRunners:      2,
+		MaxRunners:      5,
+		From:            time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		Days:            3,
+		BatchSize:       7,
+		Audit:           db.Audit{Actor: "tester", Reason: "seeding"},
+	}
+
+	first, err := migratedDB(t)
+	if err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+	if _, err := db.Seed(first, opts); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+
+	// Seeding a database which already holds data fails, unless reset.
+	if _, err := db.Seed(first, opts); !errors.Is(err, db.ErrFailedPrecondition) {
+		t.Errorf("Seed of a seeded database: err = %v, want ErrFailedPrecondition", err)
+	}
+
+	second, err := migratedDB(t)
+	if err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+	opts.BatchSize = 1000
+	if _, err := db.Seed(second, opts); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+
+	opts.Reset = true
+	result, err := db.Seed(first, opts)
+	if err != nil {
+		t.Fatalf("Seed with reset: %v", err)
+	}
+	if result.Meetings != 4 || result.Races != 12 || result.Runners < 24 || result.Runners > 60 {
+		t.Errorf("result = %+v, want 4 meetings of 3 races of 2 to 5 runners", result)
+	}
+
+	for _, table := range []string{"meetings", "races", "runners"} {
+		if got, want := dumpTable(t, first, table), dumpTable(t, second, table); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s differ between databases seeded alike", table)
+		}
+	}
+	// Races deleted by a reset are recorded in their history, along with
+	// each race created.
+	var created, deleted int
+	err = first.QueryRow(`SELECT COUNT(CASE action WHEN 'CREATED' THEN 1 END), COUNT(CASE action WHEN 'DELETED' THEN 1 END) FROM race_history WHERE actor = ?`, opts.Audit.Actor).Scan(&created, &deleted)
+	if err != nil {
+		t.Fatalf("querying race history: %v", err)
+	}
+	if created != 24 || deleted != 12 {
+		t.Errorf("race history holds %d creations and %d deletions, want 24 and 12", created, deleted)
+	}
+
+	// Runners are named in title case.
+	rows, err := first.Query(`SELECT name FROM runners`)
+	if err != nil {
+		t.Fatalf("querying runners: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatalf("scanning runner: %v", err)
+		}
+
+		for _, word := range strings.Fields(name) {
+			if r, _ := utf8.DecodeRuneInString(word); !unicode.IsUpper(r) {
+				t.Errorf("runner name %q is not in title case", name)
+				break
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("querying runners: %v", err)
+	}
+}
+
+// dumpTable returns every row of a table, in ID order, rendered as text.
+func dumpTable(t *testing.T, racingDB *sql.DB, table string) []string {
+	rows, err := racingDB.Query(`SELECT * FROM ` + table + ` ORDER BY id`)
+	if err != nil {
+		t.Fatalf("querying %s: %v", table, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		t.Fatalf("querying %s: %v", table, err)
+	}
+
+	var dump []string
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			t.Fatalf("scanning %s: %v", table, err)
+		}
+		dump = append(dump, fmt.Sprint(values...))
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("querying %s: %v", table, err)
+	}
+
+	return dump
+}
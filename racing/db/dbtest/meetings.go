@@ -0,0 +1,140 @@
+package dbtest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// TestMeetingsRepo runs the conformance suite against the meetings
+// repository created by newRepos. Each test creates its own repositories.
+func TestMeetingsRepo(t *testing.T, newRepos NewRepos) {
+	clock := db.ClockFunc(func() time.Time { return Now })
+	repos := func(t *testing.T) Repos {
+		t.Helper()
+		return newRepos(t, clock, RunnersFixture())
+	}
+
+	t.Run("ListFilters", func(t *testing.T) {
+		testListMeetingsFilters(t, repos(t))
+	})
+	t.Run("ListPaging", func(t *testing.T) {
+		testListMeetingsPaging(t, repos(t))
+	})
+	t.Run("Get", func(t *testing.T) {
+		testGetMeeting(t, repos(t))
+	})
+}
+
+func testListMeetingsFilters(t *testing.T, repos Repos) {
+	for _, tc := range []struct {
+		name   string
+		filter *racing.ListMeetingsRequestFilter
+		want   []int64
+	}{
+		{"none", nil, []int64{3, 1, 2, 4}},
+		{"ids", &racing.ListMeetingsRequestFilter{Ids: []int64{4, 2, 99}}, []int64{2, 4}},
+		{"countries", &racing.ListMeetingsRequestFilter{Countries: []string{"bra"}}, []int64{4}},
+		{"categories", &racing.ListMeetingsRequestFilter{Categories: []racing.RaceCategory{racing.RaceCategory_THOROUGHBRED}}, []int64{1, 4}},
+		{"date", &racing.ListMeetingsRequestFilter{Date: "2021-03-02"}, []int64{1, 2}},
+		{"statuses", &racing.ListMeetingsRequestFilter{Statuses: []racing.Meeting_Status{racing.Meeting_SCHEDULED, racing.Meeting_COMPLETED}}, []int64{3, 2, 4}},
+		{"combined", &racing.ListMeetingsRequestFilter{Countries: []string{"AUS"}, Categories: []racing.RaceCategory{racing.RaceCategory_THOROUGHBRED}}, []int64{1}},
+		{"no match", &racing.ListMeetingsRequestFilter{Date: "2021-03-04"}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			meetings, token, err := repos.Meetings.List(&racing.ListMeetingsRequest{Filter: tc.filter})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if token != "" {
+				t.Errorf("next page token = %q, want none", token)
+			}
+			checkIds(t, meetingIds(meetings), tc.want)
+		})
+	}
+
+	for _, tc := range []struct {
+		name  string
+		req   *racing.ListMeetingsRequest
+		field string
+	}{
+		{"unknown category", &racing.ListMeetingsRequest{Filter: &racing.ListMeetingsRequestFilter{Categories: []racing.RaceCategory{42}}}, "filter.categories"},
+		{"unspecified status", &racing.ListMeetingsRequest{Filter: &racing.ListMeetingsRequestFilter{Statuses: []racing.Meeting_Status{racing.Meeting_STATUS_UNSPECIFIED}}}, "filter.statuses"},
+		{"malformed date", &racing.ListMeetingsRequest{Filter: &racing.ListMeetingsRequestFilter{Date: "2021-3-2"}}, "filter.date"},
+		{"negative page size", &racing.ListMeetingsRequest{PageSize: -1}, "page_size"},
+		{"malformed page token", &racing.ListMeetingsRequest{PageToken: "!"}, "page_token"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := repos.Meetings.List(tc.req)
+			checkFieldError(t, err, tc.field)
+		})
+	}
+}
+
+func testListMeetingsPaging(t *testing.T, repos Repos) {
+	// Meetings 1 and 2 share a date, so a page ending on one breaks the tie
+	// by ID.
+	req := &racing.ListMeetingsRequest{
+		Filter:   &racing.ListMeetingsRequestFilter{Countries: []string{"AUS"}},
+		PageSize: 1,
+	}
+
+	var (
+		ids   []int64
+		pages int
+	)
+	for {
+		meetings, token, err := repos.Meetings.List(req)
+		if err != nil {
+			t.Fatalf("List page %d: %v", pages, err)
+		}
+		ids = append(ids, meetingIds(meetings)...)
+		pages++
+
+		if token == "" {
+			break
+		}
+		req.PageToken = token
+	}
+
+	checkIds(t, ids, []int64{3, 1, 2})
+	if pages != 3 {
+		t.Errorf("pages = %d, want 3", pages)
+	}
+
+	// A token is only valid for the request it was issued for.
+	_, token, err := repos.Meetings.List(&racing.ListMeetingsRequest{PageSize: 1})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	_, _, err = repos.Meetings.List(&racing.ListMeetingsRequest{PageSize: 1, PageToken: token, Filter: &racing.ListMeetingsRequestFilter{Countries: []string{"AUS"}}})
+	checkFieldError(t, err, "page_token")
+}
+
+func testGetMeeting(t *testing.T, repos Repos) {
+	meeting, err := repos.Meetings.Get(2)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if want := RacesFixture().Meetings[1]; !proto.Equal(meeting, want) {
+		t.Errorf("meeting = %v, want %v", meeting, want)
+	}
+
+	if _, err := repos.Meetings.Get(99); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("Get of a missing meeting: err = %v, want ErrNotFound", err)
+	}
+}
+
+func meetingIds(meetings []*racing.Meeting) []int64 {
+	ids := []int64{}
+	for _, meeting := range meetings {
+		ids = append(ids, meeting.Id)
+	}
+
+	return ids
+}
@@ -0,0 +1,157 @@
+package dbtest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// TestPricesRepo runs the conformance suite against the prices repository
+// created by newRepos. Each test creates its own repositories.
+func TestPricesRepo(t *testing.T, newRepos NewRepos) {
+	clock := db.ClockFunc(func() time.Time { return Now })
+	repos := func(t *testing.T) Repos {
+		t.Helper()
+		return newRepos(t, clock, RunnersFixture())
+	}
+
+	t.Run("UpdateValidation", func(t *testing.T) {
+		testUpdatePricesValidation(t, repos(t))
+	})
+	t.Run("Update", func(t *testing.T) {
+		testUpdatePrices(t, repos(t))
+	})
+}
+
+func testUpdatePricesValidation(t *testing.T, repos Repos) {
+	for _, tc := range []struct {
+		name  string
+		req   *racing.UpdatePricesRequest
+		field string
+	}{
+		{"no version", &racing.UpdatePricesRequest{RaceId: 1, Prices: []*racing.Price{{RunnerId: 1, WinOdds: 2, PlaceOdds: 1.5}}}, "version"},
+		{"no prices", &racing.UpdatePricesRequest{RaceId: 1, Version: 1}, "prices"},
+		{"odds of 1", &racing.UpdatePricesRequest{RaceId: 1, Version: 1, Prices: []*racing.Price{{RunnerId: 1, WinOdds: 1, PlaceOdds: 1.5}}}, "prices"},
+		{"runner priced twice", &racing.UpdatePricesRequest{RaceId: 1, Version: 1, Prices: []*racing.Price{
+			{RunnerId: 1, WinOdds: 2, PlaceOdds: 1.5},
+			{RunnerId: 1, WinOdds: 3, PlaceOdds: 1.8},
+		}}, "prices"},
+		{"runner of another race", &racing.UpdatePricesRequest{RaceId: 1, Version: 1, Prices: []*racing.Price{
+			{RunnerId: 1, WinOdds: 2, PlaceOdds: 1.5},
+			{RunnerId: 4, WinOdds: 3, PlaceOdds: 1.8},
+		}}, "prices"},
+		{"scratched runner", &racing.UpdatePricesRequest{RaceId: 1, Version: 1, Prices: []*racing.Price{
+			{RunnerId: 1, WinOdds: 2, PlaceOdds: 1.5},
+			{RunnerId: 3, WinOdds: 3, PlaceOdds: 1.8},
+		}}, "prices"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := repos.Prices.Update(tc.req, Now, audit)
+			checkFieldError(t, err, tc.field)
+		})
+	}
+
+	// Nothing is written by a rejected update, even for the runners it
+	// priced validly.
+	if _, _, err := repos.Prices.Get(1, false); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("Get of a race never priced: err = %v, want ErrNotFound", err)
+	}
+
+	// Markets are only traded while their race is open or suspended, even
+	// if only derived to have closed.
+	for _, tc := range []struct {
+		name   string
+		raceId int64
+		runner int64
+		want   error
+	}{
+		{"closed", 2, 4, db.ErrFailedPrecondition},
+		{"final", 5, 10, db.ErrFailedPrecondition},
+		{"missing", 99, 1, db.ErrNotFound},
+		{"suspended", 4, 9, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := repos.Prices.Update(&racing.UpdatePricesRequest{RaceId: tc.raceId, Version: 1, Prices: []*racing.Price{{RunnerId: tc.runner, WinOdds: 2, PlaceOdds: 1.5}}}, Now, audit)
+			if !errors.Is(err, tc.want) {
+				t.Errorf("Update: err = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func testUpdatePrices(t *testing.T, repos Repos) {
+	first := &racing.UpdatePricesRequest{RaceId: 1, Version: 1, Prices: []*racing.Price{
+		{RunnerId: 2, WinOdds: 4, PlaceOdds: 1.6},
+		{RunnerId: 1, WinOdds: 2.5, PlaceOdds: 1.2},
+	}}
+	market, err := repos.Prices.Update(first, Now, audit)
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	checkMarket(t, market, &racing.Market{RaceId: 1, Version: 1, Prices: []*racing.Price{
+		{RunnerId: 1, WinOdds: 2.5, PlaceOdds: 1.2, Version: 1, UpdatedTime: at(0)},
+		{RunnerId: 2, WinOdds: 4, PlaceOdds: 1.6, Version: 1, UpdatedTime: at(0)},
+	}})
+
+	// Versions must increase, though they may skip.
+	stale := &racing.UpdatePricesRequest{RaceId: 1, Version: 1, Prices: []*racing.Price{{RunnerId: 1, WinOdds: 3, PlaceOdds: 1.4}}}
+	if _, err := repos.Prices.Update(stale, Now.Add(time.Minute), audit); !errors.Is(err, db.ErrFailedPrecondition) {
+		t.Errorf("Update at the version of the market: err = %v, want ErrFailedPrecondition", err)
+	}
+
+	third := &racing.UpdatePricesRequest{RaceId: 1, Version: 3, Prices: []*racing.Price{{RunnerId: 1, WinOdds: 2.25, PlaceOdds: 1.2}}}
+	if _, err := repos.Prices.Update(third, Now.Add(2*time.Minute), audit); err != nil {
+		t.Fatalf("Update skipping a version: %v", err)
+	}
+
+	for _, version := range []int64{2, 3} {
+		stale := &racing.UpdatePricesRequest{RaceId: 1, Version: version, Prices: []*racing.Price{{RunnerId: 1, WinOdds: 3, PlaceOdds: 1.4}}}
+		if _, err := repos.Prices.Update(stale, Now.Add(3*time.Minute), audit); !errors.Is(err, db.ErrFailedPrecondition) {
+			t.Errorf("Update at version %d of a market at version 3: err = %v, want ErrFailedPrecondition", version, err)
+		}
+	}
+
+	// Runners left out of an update keep their prices.
+	market, history, err := repos.Prices.Get(1, true)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	checkMarket(t, market, &racing.Market{RaceId: 1, Version: 3, Prices: []*racing.Price{
+		{RunnerId: 1, WinOdds: 2.25, PlaceOdds: 1.2, Version: 3, UpdatedTime: at(2 * time.Minute)},
+		{RunnerId: 2, WinOdds: 4, PlaceOdds: 1.6, Version: 1, UpdatedTime: at(0)},
+	}})
+
+	// Every price applied is kept in the history, in the order applied.
+	want := []*racing.Price{
+		{RunnerId: 2, WinOdds: 4, PlaceOdds: 1.6, Version: 1, UpdatedTime: at(0)},
+		{RunnerId: 1, WinOdds: 2.5, PlaceOdds: 1.2, Version: 1, UpdatedTime: at(0)},
+		{RunnerId: 1, WinOdds: 2.25, PlaceOdds: 1.2, Version: 3, UpdatedTime: at(2 * time.Minute)},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("history = %v, want %v", history, want)
+	}
+	for i := range history {
+		if !proto.Equal(history[i], want[i]) {
+			t.Errorf("history %d = %v, want %v", i, history[i], want[i])
+		}
+	}
+
+	// Once the race has closed, its market no longer trades.
+	closed := &racing.UpdatePricesRequest{RaceId: 1, Version: 4, Prices: []*racing.Price{{RunnerId: 1, WinOdds: 3, PlaceOdds: 1.4}}}
+	if _, err := repos.Prices.Update(closed, Now.Add(2*time.Hour), audit); !errors.Is(err, db.ErrFailedPrecondition) {
+		t.Errorf("Update of a closed race: err = %v, want ErrFailedPrecondition", err)
+	}
+}
+
+func checkMarket(t *testing.T, got, want *racing.Market) {
+	t.Helper()
+
+	if !proto.Equal(got, want) {
+		t.Errorf("market = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,1067 @@
+// Package dbtest is a conformance suite for implementations of the racing
+// repositories, run against both the SQLite and in-memory repositories so
+// that either may stand in for the other.
+package dbtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/protobuf/proto"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// Now is the time the clock of every repository under test is fixed at.
+var Now = time.Date(2021, 3, 2, 12, 0, 0, 0, time.UTC)
+
+// audit is who every write made by the suite is attributed to.
+var audit = db.Audit{Actor: "dbtest", Reason: "conformance"}
+
+// NewRacesRepo creates the races repository under test, holding exactly the
+// data of fixture and deriving race status from clock.
+type NewRacesRepo func(t *testing.T, clock db.Clock, fixture db.RacesFixture) db.RacesRepo
+
+// RacesFixture returns the data the suite creates each repository with.
+// Relative to Now, its races are:
+//
+//	1  Flemington Cup     meeting 1  visible    +1h   OPEN
+//	2  Melbourne Stakes   meeting 1  visible    -1h   OPEN, so CLOSED
+//	3  Wentworth Sprint   meeting 2  invisible  +2h   OPEN
+//	4  Cup Final          meeting 2  visible    +30m  SUSPENDED
+//	5  Harness Classic    meeting 3  visible    -3h   FINAL, resulted
+//	6  100% Pure_Cup      meeting 3  visible    +3h   OPEN
+//
+// Meetings 1 and 2 are in Melbourne and Sydney, where races 1 and 3 start on
+// the day after the others. Meeting 3 has no time zone, and meeting 4, in São
+// Paulo, no races. Ordered by date, the meetings are 3, 1, 2 and 4.
+func RacesFixture() db.RacesFixture {
+	return db.RacesFixture{
+		Meetings: []*racing.Meeting{
+			{Id: 1, Name: "Flemington", Country: "AUS", Date: "2021-03-02", Category: racing.RaceCategory_THOROUGHBRED, Status: racing.Meeting_ACTIVE, TimeZone: "Australia/Melbourne"},
+			{Id: 2, Name: "Wentworth Park", Country: "AUS", Date: "2021-03-02", Category: racing.RaceCategory_GREYHOUND, Status: racing.Meeting_SCHEDULED, TimeZone: "Australia/Sydney"},
+			{Id: 3, Name: "Menangle", Country: "AUS", Date: "2021-03-01", Category: racing.RaceCategory_HARNESS, Status: racing.Meeting_COMPLETED},
+			{Id: 4, Name: "Cidade Jardim", Country: "BRA", Date: "2021-03-03", Category: racing.RaceCategory_THOROUGHBRED, Status: racing.Meeting_SCHEDULED, TimeZone: "America/Sao_Paulo"},
+		},
+		Races: []*racing.Race{
+			{Id: 1, MeetingId: 1, Name: "Flemington Cup", Number: 1, Visible: true, AdvertisedStartTime: at(time.Hour), Status: racing.RaceStatus_OPEN, Category: racing.RaceCategory_THOROUGHBRED},
+			{Id: 2, MeetingId: 1, Name: "Melbourne Stakes", Number: 2, Visible: true, AdvertisedStartTime: at(-time.Hour), Status: racing.RaceStatus_OPEN, Category: racing.RaceCategory_THOROUGHBRED},
+			{Id: 3, MeetingId: 2, Name: "Wentworth Sprint", Number: 1, Visible: false, AdvertisedStartTime: at(2 * time.Hour), Status: racing.RaceStatus_OPEN, Category: racing.RaceCategory_GREYHOUND},
+			{Id: 4, MeetingId: 2, Name: "Cup Final", Number: 2, Visible: true, AdvertisedStartTime: at(30 * time.Minute), Status: racing.RaceStatus_SUSPENDED, Category: racing.RaceCategory_GREYHOUND},
+			{Id: 5, MeetingId: 3, Name: "Harness Classic", Number: 3, Visible: true, AdvertisedStartTime: at(-3 * time.Hour), Status: racing.RaceStatus_FINAL, Category: racing.RaceCategory_HARNESS},
+			{Id: 6, MeetingId: 3, Name: "100% Pure_Cup", Number: 1, Visible: true, AdvertisedStartTime: at(3 * time.Hour), Status: racing.RaceStatus_OPEN, Category: racing.RaceCategory_HARNESS},
+		},
+		ResultedRaceIds: []int64{5},
+	}
+}
+
+// TestRacesRepo runs the conformance suite against the races repository
+// created by newRepo. Each test creates its own repository.
+func TestRacesRepo(t *testing.T, newRepo NewRacesRepo) {
+	clock := db.ClockFunc(func() time.Time { return Now })
+	repo := func(t *testing.T) db.RacesRepo {
+		t.Helper()
+		return newRepo(t, clock, RacesFixture())
+	}
+
+	t.Run("ListFilters", func(t *testing.T) {
+		testListFilters(t, repo(t))
+	})
+	t.Run("ListOrdering", func(t *testing.T) {
+		testListOrdering(t, repo(t))
+	})
+	t.Run("ListPaging", func(t *testing.T) {
+		testListPaging(t, repo(t))
+	})
+	t.Run("PageTokens", func(t *testing.T) {
+		testPageTokens(t, repo(t))
+	})
+	t.Run("StatusDerivation", func(t *testing.T) {
+		testStatusDerivation(t, repo(t))
+	})
+	t.Run("ReadMask", func(t *testing.T) {
+		testReadMask(t, repo(t))
+	})
+	t.Run("Get", func(t *testing.T) {
+		testGet(t, repo(t))
+	})
+	t.Run("NextToJump", func(t *testing.T) {
+		testNextToJump(t, repo(t))
+	})
+	t.Run("TransitionRace", func(t *testing.T) {
+		testTransitionRace(t, repo(t))
+	})
+	t.Run("CreateUpdateDelete", func(t *testing.T) {
+		testCreateUpdateDelete(t, repo(t))
+	})
+	t.Run("Import", func(t *testing.T) {
+		testImport(t, repo(t))
+	})
+	t.Run("Search", func(t *testing.T) {
+		testSearch(t, repo(t))
+	})
+	t.Run("History", func(t *testing.T) {
+		testHistory(t, repo(t))
+	})
+	t.Run("Archive", func(t *testing.T) {
+		testArchive(t, repo(t))
+	})
+	t.Run("LocalDate", func(t *testing.T) {
+		testLocalDate(t, repo(t))
+	})
+}
+
+func testListFilters(t *testing.T, repo db.RacesRepo) {
+	for _, tc := range []struct {
+		name   string
+		filter *racing.ListRacesRequestFilter
+		want   []int64
+	}{
+		{"none", nil, []int64{6, 3, 1, 4, 2, 5}},
+		{"ids", &racing.ListRacesRequestFilter{Ids: []int64{2, 4, 99}}, []int64{4, 2}},
+		{"meeting ids", &racing.ListRacesRequestFilter{MeetingIds: []int64{1, 3}}, []int64{6, 1, 2, 5}},
+		{"visible only", &racing.ListRacesRequestFilter{VisibleOnly: true}, []int64{6, 1, 4, 2, 5}},
+		{"start from", &racing.ListRacesRequestFilter{AdvertisedStartFrom: at(time.Hour)}, []int64{6, 3, 1}},
+		{"start to", &racing.ListRacesRequestFilter{AdvertisedStartTo: at(time.Hour)}, []int64{4, 2, 5}},
+		{"start between", &racing.ListRacesRequestFilter{AdvertisedStartFrom: at(-time.Hour), AdvertisedStartTo: at(time.Hour)}, []int64{4, 2}},
+		{"derived status", &racing.ListRacesRequestFilter{Statuses: []racing.RaceStatus{racing.RaceStatus_CLOSED}}, []int64{2}},
+		{"statuses", &racing.ListRacesRequestFilter{Statuses: []racing.RaceStatus{racing.RaceStatus_OPEN, racing.RaceStatus_FINAL}}, []int64{6, 3, 1, 5}},
+		{"name contains", &racing.ListRacesRequestFilter{NameContains: "CUP"}, []int64{6, 1, 4}},
+		{"name contains wildcards", &racing.ListRacesRequestFilter{NameContains: "% Pure_"}, []int64{6}},
+		{"numbers", &racing.ListRacesRequestFilter{Numbers: []int64{2, 3}}, []int64{4, 2, 5}},
+		{"resulted only", &racing.ListRacesRequestFilter{ResultedOnly: true}, []int64{5}},
+		{"categories", &racing.ListRacesRequestFilter{Categories: []racing.RaceCategory{racing.RaceCategory_GREYHOUND}}, []int64{3, 4}},
+		{"combined", &racing.ListRacesRequestFilter{VisibleOnly: true, MeetingIds: []int64{2}}, []int64{4}},
+		{"local date", &racing.ListRacesRequestFilter{LocalDate: "2021-03-02"}, []int64{6, 4, 2, 5}},
+		{"next local date", &racing.ListRacesRequestFilter{LocalDate: "2021-03-03"}, []int64{3, 1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			races, token, err := repo.List(&racing.ListRacesRequest{Filter: tc.filter})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if token != "" {
+				t.Errorf("next page token = %q, want none", token)
+			}
+			checkIds(t, raceIds(races), tc.want)
+		})
+	}
+
+	for _, tc := range []struct {
+		name  string
+		req   *racing.ListRacesRequest
+		field string
+	}{
+		{"unknown status", &racing.ListRacesRequest{Filter: &racing.ListRacesRequestFilter{Statuses: []racing.RaceStatus{racing.RaceStatus_STATUS_UNSPECIFIED}}}, "filter.statuses"},
+		{"unknown category", &racing.ListRacesRequest{Filter: &racing.ListRacesRequestFilter{Categories: []racing.RaceCategory{42}}}, "filter.categories"},
+		{"empty start range", &racing.ListRacesRequest{Filter: &racing.ListRacesRequestFilter{AdvertisedStartFrom: at(0), AdvertisedStartTo: at(0)}}, "filter.advertised_start_from"},
+		{"malformed local date", &racing.ListRacesRequest{Filter: &racing.ListRacesRequestFilter{LocalDate: "2021-3-2"}}, "filter.local_date"},
+		{"negative page size", &racing.ListRacesRequest{PageSize: -1}, "page_size"},
+		{"unknown order", &racing.ListRacesRequest{OrderBy: []*racing.RaceOrder{{Field: 42}}}, "order_by"},
+		{"unspecified order", &racing.ListRacesRequest{OrderBy: []*racing.RaceOrder{{}}}, "order_by"},
+		{"repeated order", &racing.ListRacesRequest{OrderBy: []*racing.RaceOrder{{Field: racing.RaceOrder_NAME}, {Field: racing.RaceOrder_NAME, Direction: racing.RaceOrder_DESC}}}, "order_by"},
+		{"unknown direction", &racing.ListRacesRequest{OrderBy: []*racing.RaceOrder{{Field: racing.RaceOrder_NAME, Direction: 42}}}, "order_by"},
+		{"malformed page token", &racing.ListRacesRequest{PageToken: "!"}, "page_token"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := repo.List(tc.req)
+			checkFieldError(t, err, tc.field)
+		})
+	}
+}
+
+func testListOrdering(t *testing.T, repo db.RacesRepo) {
+	for _, tc := range []struct {
+		name    string
+		orderBy []*racing.RaceOrder
+		want    []int64
+	}{
+		{"start ascending", []*racing.RaceOrder{{Field: racing.RaceOrder_ADVERTISED_START_TIME}}, []int64{5, 2, 4, 1, 3, 6}},
+		{"name", []*racing.RaceOrder{{Field: racing.RaceOrder_NAME}}, []int64{6, 4, 1, 5, 2, 3}},
+		{"number then id descending", []*racing.RaceOrder{
+			{Field: racing.RaceOrder_NUMBER},
+			{Field: racing.RaceOrder_ID, Direction: racing.RaceOrder_DESC},
+		}, []int64{6, 3, 1, 4, 2, 5}},
+		{"meeting descending then start", []*racing.RaceOrder{
+			{Field: racing.RaceOrder_MEETING_ID, Direction: racing.RaceOrder_DESC},
+			{Field: racing.RaceOrder_ADVERTISED_START_TIME},
+		}, []int64{5, 6, 4, 3, 2, 1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			races, _, err := repo.List(&racing.ListRacesRequest{OrderBy: tc.orderBy})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			checkIds(t, raceIds(races), tc.want)
+		})
+	}
+}
+
+func testListPaging(t *testing.T, repo db.RacesRepo) {
+	req := &racing.ListRacesRequest{
+		Filter:   &racing.ListRacesRequestFilter{VisibleOnly: true},
+		OrderBy:  []*racing.RaceOrder{{Field: racing.RaceOrder_MEETING_ID}, {Field: racing.RaceOrder_NAME, Direction: racing.RaceOrder_DESC}},
+		PageSize: 2,
+	}
+
+	var (
+		ids   []int64
+		pages int
+	)
+	for {
+		races, token, err := repo.List(req)
+		if err != nil {
+			t.Fatalf("List page %d: %v", pages, err)
+		}
+		ids = append(ids, raceIds(races)...)
+		pages++
+
+		if token == "" {
+			break
+		}
+		req.PageToken = token
+	}
+
+	checkIds(t, ids, []int64{2, 1, 4, 5, 6})
+	if pages != 3 {
+		t.Errorf("pages = %d, want 3", pages)
+	}
+
+	// A token is only valid for the request it was issued for.
+	_, token, err := repo.List(&racing.ListRacesRequest{PageSize: 1})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	_, _, err = repo.List(&racing.ListRacesRequest{PageSize: 1, PageToken: token, Filter: &racing.ListRacesRequestFilter{VisibleOnly: true}})
+	checkFieldError(t, err, "page_token")
+}
+
+func testPageTokens(t *testing.T, repo db.RacesRepo) {
+	// Races 1, 3 and 6 tie on number 1, and races 2 and 4 on number 2, so
+	// pages of one break every tie by ID.
+	byNumber := []*racing.RaceOrder{{Field: racing.RaceOrder_NUMBER}}
+	req := &racing.ListRacesRequest{OrderBy: byNumber, PageSize: 1}
+
+	var ids []int64
+	for pages := 0; ; pages++ {
+		races, token, err := repo.List(req)
+		if err != nil {
+			t.Fatalf("List page %d: %v", pages, err)
+		}
+		ids = append(ids, raceIds(races)...)
+
+		// Once on the third race, one already listed is deleted and one
+		// tying with the current is created. Pages resume after the last
+		// race listed, so neither shifts those to come.
+		if pages == 1 {
+			if err := repo.Delete(1, "", audit); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+			if _, err := repo.Create(&racing.Race{MeetingId: 3, Name: "Late Entry", Number: 1, AdvertisedStartTime: at(time.Hour)}, audit); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+		}
+
+		if token == "" {
+			break
+		}
+		req.PageToken = token
+	}
+	checkIds(t, ids, []int64{1, 3, 6, 7, 2, 4, 5})
+
+	_, token, err := repo.List(&racing.ListRacesRequest{OrderBy: byNumber, PageSize: 1})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if token == "" {
+		t.Fatal("next page token = none, want one")
+	}
+
+	for _, tc := range []struct {
+		name string
+		req  *racing.ListRacesRequest
+	}{
+		{"different filter", &racing.ListRacesRequest{OrderBy: byNumber, PageToken: token, Filter: &racing.ListRacesRequestFilter{VisibleOnly: true}}},
+		{"different order", &racing.ListRacesRequest{OrderBy: []*racing.RaceOrder{{Field: racing.RaceOrder_NAME}}, PageToken: token}},
+		{"different direction", &racing.ListRacesRequest{OrderBy: []*racing.RaceOrder{{Field: racing.RaceOrder_NUMBER, Direction: racing.RaceOrder_DESC}}, PageToken: token}},
+		{"tampered checksum", &racing.ListRacesRequest{OrderBy: byNumber, PageToken: tamperToken(t, token, func(token map[string]interface{}) {
+			token["c"] = token["c"].(float64) + 1
+		})}},
+		{"missing key", &racing.ListRacesRequest{OrderBy: byNumber, PageToken: tamperToken(t, token, func(token map[string]interface{}) {
+			token["k"] = token["k"].([]interface{})[:1]
+		})}},
+		{"mistyped key", &racing.ListRacesRequest{OrderBy: byNumber, PageToken: tamperToken(t, token, func(token map[string]interface{}) {
+			token["k"].([]interface{})[0] = "one"
+		})}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := repo.List(tc.req)
+			checkFieldError(t, err, "page_token")
+		})
+	}
+}
+
+// tamperToken decodes a page token, changes it, and encodes it again, as a
+// caller forging a token might. Tokens are base64 encoded JSON, holding the
+// checksum of the request as "c" and the keys of the last race as "k".
+func tamperToken(t *testing.T, token string, tamper func(map[string]interface{})) string {
+	t.Helper()
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		t.Fatalf("decoding page token: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("decoding page token: %v", err)
+	}
+	tamper(decoded)
+
+	if b, err = json.Marshal(decoded); err != nil {
+		t.Fatalf("encoding page token: %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func testStatusDerivation(t *testing.T, repo db.RacesRepo) {
+	want := map[int64]racing.RaceStatus{
+		1: racing.RaceStatus_OPEN,
+		2: racing.RaceStatus_CLOSED,
+		3: racing.RaceStatus_OPEN,
+		4: racing.RaceStatus_SUSPENDED,
+		5: racing.RaceStatus_FINAL,
+		6: racing.RaceStatus_OPEN,
+	}
+
+	races, _, err := repo.List(&racing.ListRacesRequest{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	for _, race := range races {
+		if race.Status != want[race.Id] {
+			t.Errorf("race %d status = %s, want %s", race.Id, race.Status, want[race.Id])
+		}
+	}
+
+	// Before its start time passed, race 2 was still open, and a race is
+	// open up to and including its start time.
+	for _, asOf := range []*timestamp.Timestamp{at(-2 * time.Hour), at(-time.Hour)} {
+		race, err := repo.GetRaceById(2, asOf, nil)
+		if err != nil {
+			t.Fatalf("GetRaceById: %v", err)
+		}
+		if race.Status != racing.RaceStatus_OPEN {
+			t.Errorf("race 2 status as of %s = %s, want OPEN", asOf.AsTime(), race.Status)
+		}
+	}
+
+	// Statuses are filtered on as derived at the same instant.
+	races, _, err = repo.List(&racing.ListRacesRequest{
+		Filter: &racing.ListRacesRequestFilter{Statuses: []racing.RaceStatus{racing.RaceStatus_CLOSED}},
+		AsOf:   at(90 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	checkIds(t, raceIds(races), []int64{1, 2})
+}
+
+func testReadMask(t *testing.T, repo db.RacesRepo) {
+	races, token, err := repo.List(&racing.ListRacesRequest{
+		ReadMask: &field_mask.FieldMask{Paths: []string{"name"}},
+		PageSize: 1,
+	})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	// Fields ordered on are read along with those masked, to page on.
+	race := races[0]
+	if race.Name != "100% Pure_Cup" || race.Id != 6 || race.AdvertisedStartTime == nil {
+		t.Errorf("race = %v, want its name, ID and start time", race)
+	}
+	if race.MeetingId != 0 || race.Status != racing.RaceStatus_STATUS_UNSPECIFIED || race.Etag != "" {
+		t.Errorf("race = %v, want no other fields", race)
+	}
+
+	if _, _, err := repo.List(&racing.ListRacesRequest{ReadMask: &field_mask.FieldMask{Paths: []string{"name"}}, PageToken: token}); err != nil {
+		t.Errorf("List next page: %v", err)
+	}
+
+	race, err = repo.GetRaceById(2, nil, &field_mask.FieldMask{Paths: []string{"status"}})
+	if err != nil {
+		t.Fatalf("GetRaceById: %v", err)
+	}
+	if race.Status != racing.RaceStatus_CLOSED || race.Name != "" {
+		t.Errorf("race = %v, want only its status", race)
+	}
+
+	_, err = repo.GetRaceById(2, nil, &field_mask.FieldMask{Paths: []string{"runners"}})
+	checkFieldError(t, err, "read_mask")
+}
+
+func testGet(t *testing.T, repo db.RacesRepo) {
+	race, err := repo.GetRaceById(1, nil, nil)
+	if err != nil {
+		t.Fatalf("GetRaceById: %v", err)
+	}
+	want := RacesFixture().Races[0]
+	want.Etag = "1"
+	checkRace(t, race, want)
+
+	if _, err := repo.GetRaceById(99, nil, nil); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("GetRaceById of a missing race: err = %v, want ErrNotFound", err)
+	}
+
+	races, missing, err := repo.BatchGet(&racing.BatchGetRacesRequest{RaceIds: []int64{4, 99, 1, 4, 99}, AllowMissing: true})
+	if err != nil {
+		t.Fatalf("BatchGet: %v", err)
+	}
+	checkIds(t, raceIds(races), []int64{4, 1, 4})
+	checkIds(t, missing, []int64{99})
+
+	if _, _, err := repo.BatchGet(&racing.BatchGetRacesRequest{RaceIds: []int64{1, 99}}); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("BatchGet of a missing race: err = %v, want ErrNotFound", err)
+	}
+}
+
+func testNextToJump(t *testing.T, repo db.RacesRepo) {
+	for _, tc := range []struct {
+		name string
+		req  *racing.ListNextToJumpRequest
+		want []int64
+	}{
+		// Invisible, suspended and closed races never jump.
+		{"default", &racing.ListNextToJumpRequest{}, []int64{1, 6}},
+		{"limit", &racing.ListNextToJumpRequest{Limit: 1}, []int64{1}},
+		{"categories", &racing.ListNextToJumpRequest{Categories: []racing.RaceCategory{racing.RaceCategory_HARNESS}}, []int64{6}},
+		{"as of", &racing.ListNextToJumpRequest{AsOf: at(-2 * time.Hour)}, []int64{2, 1, 6}},
+		{"max per meeting", &racing.ListNextToJumpRequest{AsOf: at(-2 * time.Hour), MaxPerMeeting: 1}, []int64{2, 6}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			next, err := repo.NextToJump(tc.req)
+			if err != nil {
+				t.Fatalf("NextToJump: %v", err)
+			}
+
+			var ids []int64
+			for _, n := range next {
+				ids = append(ids, n.Race.Id)
+			}
+			checkIds(t, ids, tc.want)
+		})
+	}
+
+	next, err := repo.NextToJump(&racing.ListNextToJumpRequest{Limit: 1})
+	if err != nil {
+		t.Fatalf("NextToJump: %v", err)
+	}
+	if got := next[0].SecondsToJump; got != 3600 {
+		t.Errorf("seconds to jump = %d, want 3600", got)
+	}
+
+	_, err = repo.NextToJump(&racing.ListNextToJumpRequest{Limit: -1})
+	checkFieldError(t, err, "limit")
+
+	// Races of other categories do not count towards a meeting's cap, so
+	// race 1 still jumps behind a greyhound race at its meeting.
+	created, err := repo.Create(&racing.Race{MeetingId: 1, Name: "Flemington Dash", Number: 3, Visible: true, AdvertisedStartTime: at(10 * time.Minute), Category: racing.RaceCategory_GREYHOUND}, audit)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	for category, want := range map[racing.RaceCategory][]int64{
+		racing.RaceCategory_THOROUGHBRED: {1},
+		racing.RaceCategory_GREYHOUND:    {created.Id},
+	} {
+		next, err := repo.NextToJump(&racing.ListNextToJumpRequest{Categories: []racing.RaceCategory{category}, MaxPerMeeting: 1})
+		if err != nil {
+			t.Fatalf("NextToJump: %v", err)
+		}
+
+		var ids []int64
+		for _, n := range next {
+			ids = append(ids, n.Race.Id)
+		}
+		checkIds(t, ids, want)
+	}
+}
+
+func testTransitionRace(t *testing.T, repo db.RacesRepo) {
+	race, err := repo.TransitionRace(1, racing.RaceStatus_SUSPENDED, "1", audit)
+	if err != nil {
+		t.Fatalf("TransitionRace: %v", err)
+	}
+	if race.Status != racing.RaceStatus_SUSPENDED || race.Etag != "2" {
+		t.Errorf("race = %v, want SUSPENDED at etag 2", race)
+	}
+
+	if _, err := repo.TransitionRace(1, racing.RaceStatus_OPEN, "1", audit); !errors.Is(err, db.ErrAborted) {
+		t.Errorf("TransitionRace with a stale etag: err = %v, want ErrAborted", err)
+	}
+
+	if _, err := repo.TransitionRace(5, racing.RaceStatus_OPEN, "", audit); !errors.Is(err, db.ErrFailedPrecondition) {
+		t.Errorf("TransitionRace of a final race: err = %v, want ErrFailedPrecondition", err)
+	}
+
+	// Moving a race to the status it already has, even a derived one,
+	// changes nothing.
+	race, err = repo.TransitionRace(2, racing.RaceStatus_CLOSED, "", audit)
+	if err != nil {
+		t.Fatalf("TransitionRace: %v", err)
+	}
+	if race.Etag != "1" {
+		t.Errorf("race etag = %q, want it unchanged", race.Etag)
+	}
+
+	// Though not with a stale etag.
+	if _, err := repo.TransitionRace(1, racing.RaceStatus_SUSPENDED, "1", audit); !errors.Is(err, db.ErrAborted) {
+		t.Errorf("TransitionRace to the same status with a stale etag: err = %v, want ErrAborted", err)
+	}
+	if race, err = repo.TransitionRace(1, racing.RaceStatus_SUSPENDED, "2", audit); err != nil {
+		t.Fatalf("TransitionRace to the same status: %v", err)
+	}
+	if race.Etag != "2" {
+		t.Errorf("race etag = %q, want it unchanged", race.Etag)
+	}
+
+	if _, err := repo.TransitionRace(99, racing.RaceStatus_CLOSED, "", audit); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("TransitionRace of a missing race: err = %v, want ErrNotFound", err)
+	}
+
+	_, err = repo.TransitionRace(1, racing.RaceStatus_STATUS_UNSPECIFIED, "", audit)
+	checkFieldError(t, err, "status")
+}
+
+func testCreateUpdateDelete(t *testing.T, repo db.RacesRepo) {
+	created, err := repo.Create(&racing.Race{MeetingId: 2, Name: "  Maiden Dash ", Number: 3, Visible: true, AdvertisedStartTime: at(4 * time.Hour)}, audit)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Races take the next ID, and their meeting's category if they have none.
+	checkRace(t, created, &racing.Race{
+		Id: 7, MeetingId: 2, Name: "Maiden Dash", Number: 3, Visible: true, AdvertisedStartTime: at(4 * time.Hour),
+		Status: racing.RaceStatus_OPEN, Etag: "1", Category: racing.RaceCategory_GREYHOUND,
+	})
+
+	_, err = repo.Create(&racing.Race{MeetingId: 99, Name: "Nowhere", Number: 1, AdvertisedStartTime: at(0)}, audit)
+	checkFieldError(t, err, "race.meeting_id")
+
+	updated, err := repo.Update(&racing.Race{Id: 7, Name: "Maiden Plate", Number: 9, Etag: "1"}, []string{"name"}, audit)
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.Name != "Maiden Plate" || updated.Number != 3 || updated.Etag != "2" {
+		t.Errorf("updated race = %v, want only its name changed, at etag 2", updated)
+	}
+
+	if _, err := repo.Update(&racing.Race{Id: 7, Name: "Stale", Etag: "1"}, []string{"name"}, audit); !errors.Is(err, db.ErrAborted) {
+		t.Errorf("Update with a stale etag: err = %v, want ErrAborted", err)
+	}
+
+	_, err = repo.Update(&racing.Race{Id: 7}, []string{"status"}, audit)
+	checkFieldError(t, err, "update_mask")
+
+	_, err = repo.Update(&racing.Race{Id: 7, Number: -1}, []string{"number"}, audit)
+	checkFieldError(t, err, "race.number")
+
+	if _, err := repo.Update(&racing.Race{Id: 99, Name: "Missing"}, []string{"name"}, audit); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("Update of a missing race: err = %v, want ErrNotFound", err)
+	}
+
+	if err := repo.Delete(5, "", audit); !errors.Is(err, db.ErrFailedPrecondition) {
+		t.Errorf("Delete of a resulted race: err = %v, want ErrFailedPrecondition", err)
+	}
+
+	if err := repo.Delete(7, "1", audit); !errors.Is(err, db.ErrAborted) {
+		t.Errorf("Delete with a stale etag: err = %v, want ErrAborted", err)
+	}
+
+	if err := repo.Delete(7, "2", audit); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, err := repo.GetRaceById(7, nil, nil); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("GetRaceById of a deleted race: err = %v, want ErrNotFound", err)
+	}
+
+	if err := repo.Delete(7, "", audit); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("Delete of a deleted race: err = %v, want ErrNotFound", err)
+	}
+}
+
+func testImport(t *testing.T, repo db.RacesRepo) {
+	fixture := RacesFixture()
+	unchanged, changed := fixture.Races[0], fixture.Races[1]
+	changed.Name = "Melbourne Cup"
+
+	batch := []*racing.Race{
+		unchanged,
+		changed,
+		{Id: 10, MeetingId: 1, Name: "Imported", Number: 4, AdvertisedStartTime: at(time.Hour)},
+	}
+
+	result, err := repo.Import(batch, true, audit)
+	if err != nil {
+		t.Fatalf("Import dry run: %v", err)
+	}
+	if result.Created != 1 || result.Updated != 1 || result.Unchanged != 1 || result.Committed {
+		t.Errorf("dry run result = %+v, want 1 created, updated and unchanged, uncommitted", result)
+	}
+	if _, err := repo.GetRaceById(10, nil, nil); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("GetRaceById of a race imported in a dry run: err = %v, want ErrNotFound", err)
+	}
+
+	// An invalid race fails the whole batch.
+	result, err = repo.Import(append(batch, &racing.Race{Id: 11, MeetingId: 1, Number: 1, AdvertisedStartTime: at(0)}), false, audit)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if result.Committed || len(result.Errors) != 1 {
+		t.Errorf("result = %+v, want a single error, uncommitted", result)
+	}
+	var fieldErr *db.FieldError
+	if !errors.As(result.Errors[3], &fieldErr) || fieldErr.Field != "name" {
+		t.Errorf("error of race 11 = %v, want a name field error", result.Errors[3])
+	}
+
+	result, err = repo.Import(batch, false, audit)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if !result.Committed {
+		t.Errorf("result = %+v, want committed", result)
+	}
+
+	races, _, err := repo.BatchGet(&racing.BatchGetRacesRequest{RaceIds: []int64{1, 2, 10}})
+	if err != nil {
+		t.Fatalf("BatchGet: %v", err)
+	}
+	if races[0].Etag != "1" || races[1].Name != "Melbourne Cup" || races[1].Etag != "2" || races[1].Status != racing.RaceStatus_CLOSED {
+		t.Errorf("races = %v, want race 1 unchanged and race 2 renamed", races[:2])
+	}
+	if races[2].Category != racing.RaceCategory_THOROUGHBRED || races[2].Status != racing.RaceStatus_OPEN {
+		t.Errorf("imported race = %v, want an open thoroughbred race", races[2])
+	}
+}
+
+func testSearch(t *testing.T, repo db.RacesRepo) {
+	results, _, err := repo.Search(&racing.SearchRacesRequest{Query: "cup"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	// Shorter names, in which a match counts for more, are more relevant,
+	// and equally relevant races are ordered by ID.
+	var ids []int64
+	for i, result := range results {
+		ids = append(ids, result.Race.Id)
+
+		if !strings.Contains(result.Snippet, "<mark>Cup</mark>") {
+			t.Errorf("snippet = %q, want Cup marked", result.Snippet)
+		}
+		if i > 0 && result.Score > results[i-1].Score {
+			t.Errorf("result %d scores %v, more than the result before it", i, result.Score)
+		}
+	}
+	checkIds(t, ids, []int64{1, 4, 6})
+
+	// Words match the start of words in names, ignoring case and diacritics.
+	results, _, err = repo.Search(&racing.SearchRacesRequest{Query: "FLÉM cu", Filter: &racing.ListRacesRequestFilter{VisibleOnly: true}})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Snippet != "<mark>Flemington</mark> <mark>Cup</mark>" {
+		t.Errorf("results = %v, want race 1 with both words marked", results)
+	}
+
+	var paged []int64
+	req := &racing.SearchRacesRequest{Query: "cup", PageSize: 1}
+	for {
+		results, token, err := repo.Search(req)
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		for _, result := range results {
+			paged = append(paged, result.Race.Id)
+		}
+
+		if token == "" {
+			break
+		}
+		req.PageToken = token
+	}
+	checkIds(t, paged, ids)
+
+	// A token is only valid for the search it was issued for, as its score
+	// only orders the matches of the same query.
+	_, token, err := repo.Search(&racing.SearchRacesRequest{Query: "cup", PageSize: 1})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	for _, tc := range []struct {
+		name string
+		req  *racing.SearchRacesRequest
+	}{
+		{"different query", &racing.SearchRacesRequest{Query: "final", PageSize: 1, PageToken: token}},
+		{"different filter", &racing.SearchRacesRequest{Query: "cup", PageSize: 1, PageToken: token, Filter: &racing.ListRacesRequestFilter{VisibleOnly: true}}},
+		{"tampered checksum", &racing.SearchRacesRequest{Query: "cup", PageSize: 1, PageToken: tamperToken(t, token, func(token map[string]interface{}) {
+			token["c"] = token["c"].(float64) + 1
+		})}},
+		{"missing key", &racing.SearchRacesRequest{Query: "cup", PageSize: 1, PageToken: tamperToken(t, token, func(token map[string]interface{}) {
+			token["k"] = token["k"].([]interface{})[:1]
+		})}},
+		{"mistyped key", &racing.SearchRacesRequest{Query: "cup", PageSize: 1, PageToken: tamperToken(t, token, func(token map[string]interface{}) {
+			token["k"].([]interface{})[0] = "one"
+		})}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := repo.Search(tc.req)
+			checkFieldError(t, err, "page_token")
+		})
+	}
+
+	_, _, err = repo.Search(&racing.SearchRacesRequest{Query: " ?! "})
+	checkFieldError(t, err, "query")
+}
+
+func testHistory(t *testing.T, repo db.RacesRepo) {
+	steward := db.Audit{Actor: "steward", Reason: "moved for the weather"}
+
+	if _, err := repo.Create(&racing.Race{MeetingId: 2, Name: "Maiden Dash", Number: 3, Visible: true, AdvertisedStartTime: at(4 * time.Hour)}, audit); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := repo.Update(&racing.Race{Id: 7, AdvertisedStartTime: at(5 * time.Hour), Etag: "1"}, []string{"advertised_start_time"}, steward); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := repo.TransitionRace(7, racing.RaceStatus_SUSPENDED, "", audit); err != nil {
+		t.Fatalf("TransitionRace: %v", err)
+	}
+
+	// Writes that change nothing, or fail, are not recorded.
+	if _, err := repo.TransitionRace(7, racing.RaceStatus_SUSPENDED, "", audit); err != nil {
+		t.Fatalf("TransitionRace: %v", err)
+	}
+	if _, err := repo.Update(&racing.Race{Id: 7, Name: "Stale", Etag: "1"}, []string{"name"}, audit); !errors.Is(err, db.ErrAborted) {
+		t.Errorf("Update with a stale etag: err = %v, want ErrAborted", err)
+	}
+
+	if err := repo.Delete(7, "", audit); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	changes, token, err := repo.History(&racing.ListRaceHistoryRequest{RaceId: 7})
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if token != "" {
+		t.Errorf("next page token = %q, want none", token)
+	}
+
+	start, moved := at(4*time.Hour).AsTime().Format(time.RFC3339Nano), at(5*time.Hour).AsTime().Format(time.RFC3339Nano)
+	created := []*racing.FieldChange{
+		{Field: "meeting_id", NewValue: "2"},
+		{Field: "name", NewValue: "Maiden Dash"},
+		{Field: "number", NewValue: "3"},
+		{Field: "visible", NewValue: "true"},
+		{Field: "advertised_start_time", NewValue: start},
+		{Field: "status", NewValue: "OPEN"},
+		{Field: "category", NewValue: "GREYHOUND"},
+	}
+	deleted := []*racing.FieldChange{
+		{Field: "meeting_id", OldValue: "2"},
+		{Field: "name", OldValue: "Maiden Dash"},
+		{Field: "number", OldValue: "3"},
+		{Field: "visible", OldValue: "true"},
+		{Field: "advertised_start_time", OldValue: moved},
+		{Field: "status", OldValue: "SUSPENDED"},
+		{Field: "category", OldValue: "GREYHOUND"},
+	}
+	want := []*racing.RaceChange{
+		{RaceId: 7, Action: racing.RaceChange_CREATED, Actor: audit.Actor, Reason: audit.Reason, ChangeTime: at(0), Etag: "1", Fields: created},
+		{RaceId: 7, Action: racing.RaceChange_UPDATED, Actor: steward.Actor, Reason: steward.Reason, ChangeTime: at(0), Etag: "2", Fields: []*racing.FieldChange{
+			{Field: "advertised_start_time", OldValue: start, NewValue: moved},
+		}},
+		{RaceId: 7, Action: racing.RaceChange_UPDATED, Actor: audit.Actor, Reason: audit.Reason, ChangeTime: at(0), Etag: "3", Fields: []*racing.FieldChange{
+			{Field: "status", OldValue: "OPEN", NewValue: "SUSPENDED"},
+		}},
+		{RaceId: 7, Action: racing.RaceChange_DELETED, Actor: audit.Actor, Reason: audit.Reason, ChangeTime: at(0), Etag: "3", Fields: deleted},
+	}
+	checkChanges(t, changes, want)
+
+	var paged []*racing.RaceChange
+	req := &racing.ListRaceHistoryRequest{RaceId: 7, PageSize: 1}
+	for {
+		changes, token, err := repo.History(req)
+		if err != nil {
+			t.Fatalf("History: %v", err)
+		}
+		paged = append(paged, changes...)
+
+		if token == "" {
+			break
+		}
+		req.PageToken = token
+	}
+	checkChanges(t, paged, want)
+
+	// Only the races an import changes are recorded, and only once it is
+	// committed.
+	fixture := RacesFixture()
+	unchanged, changed := fixture.Races[0], fixture.Races[1]
+	changed.Name = "Melbourne Cup"
+	for _, dryRun := range []bool{true, false} {
+		if _, err := repo.Import([]*racing.Race{unchanged, changed}, dryRun, audit); err != nil {
+			t.Fatalf("Import: %v", err)
+		}
+	}
+
+	for id, want := range map[int64][]*racing.RaceChange{
+		1: nil,
+		2: {{RaceId: 2, Action: racing.RaceChange_UPDATED, Actor: audit.Actor, Reason: audit.Reason, ChangeTime: at(0), Etag: "2", Fields: []*racing.FieldChange{
+			{Field: "name", OldValue: "Melbourne Stakes", NewValue: "Melbourne Cup"},
+		}}},
+	} {
+		changes, _, err := repo.History(&racing.ListRaceHistoryRequest{RaceId: id})
+		if err != nil {
+			t.Fatalf("History: %v", err)
+		}
+		checkChanges(t, changes, want)
+	}
+
+	// Status is recorded as persisted, so a race is never recorded closing
+	// as its start time passes, whether by time or by moving its start.
+	past := at(-2 * time.Hour)
+	if _, err := repo.Update(&racing.Race{Id: 1, AdvertisedStartTime: past}, []string{"advertised_start_time"}, audit); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := repo.TransitionRace(2, racing.RaceStatus_INTERIM, "", audit); err != nil {
+		t.Fatalf("TransitionRace: %v", err)
+	}
+
+	for id, want := range map[int64]*racing.RaceChange{
+		1: {RaceId: 1, Action: racing.RaceChange_UPDATED, Actor: audit.Actor, Reason: audit.Reason, ChangeTime: at(0), Etag: "2", Fields: []*racing.FieldChange{
+			{Field: "advertised_start_time", OldValue: at(time.Hour).AsTime().Format(time.RFC3339Nano), NewValue: past.AsTime().Format(time.RFC3339Nano)},
+		}},
+		2: {RaceId: 2, Action: racing.RaceChange_UPDATED, Actor: audit.Actor, Reason: audit.Reason, ChangeTime: at(0), Etag: "3", Fields: []*racing.FieldChange{
+			{Field: "status", OldValue: "OPEN", NewValue: "INTERIM"},
+		}},
+	} {
+		changes, _, err := repo.History(&racing.ListRaceHistoryRequest{RaceId: id})
+		if err != nil {
+			t.Fatalf("History: %v", err)
+		}
+		checkChanges(t, changes[len(changes)-1:], []*racing.RaceChange{want})
+	}
+
+	_, _, err = repo.History(&racing.ListRaceHistoryRequest{})
+	checkFieldError(t, err, "race_id")
+}
+
+func testArchive(t *testing.T, repo db.RacesRepo) {
+	// Races 2 and 5 have finished, race 5 first. At most limit races are
+	// archived, oldest first.
+	if _, err := repo.TransitionRace(2, racing.RaceStatus_ABANDONED, "", audit); err != nil {
+		t.Fatalf("TransitionRace: %v", err)
+	}
+
+	ids, err := repo.Archive(30*time.Minute, 1, audit)
+	if err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+	checkIds(t, ids, []int64{5})
+
+	races, _, err := repo.List(&racing.ListRacesRequest{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	checkIds(t, raceIds(races), []int64{6, 3, 1, 4, 2})
+
+	races, _, err = repo.List(&racing.ListRacesRequest{Filter: &racing.ListRacesRequestFilter{IncludeArchived: true, ResultedOnly: true}})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	checkIds(t, raceIds(races), []int64{5})
+
+	results, _, err := repo.Search(&racing.SearchRacesRequest{Query: "classic"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("search results = %v, want none", results)
+	}
+
+	// Archived races are still fetched by ID.
+	race, err := repo.GetRaceById(5, nil, nil)
+	if err != nil {
+		t.Fatalf("GetRaceById: %v", err)
+	}
+	want := RacesFixture().Races[4]
+	want.Etag, want.ArchivedTime = "2", at(0)
+	checkRace(t, race, want)
+
+	changes, _, err := repo.History(&racing.ListRaceHistoryRequest{RaceId: 5})
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	checkChanges(t, changes, []*racing.RaceChange{
+		{RaceId: 5, Action: racing.RaceChange_UPDATED, Actor: audit.Actor, Reason: audit.Reason, ChangeTime: at(0), Etag: "2", Fields: []*racing.FieldChange{
+			{Field: "archived_time", NewValue: Now.Format(time.RFC3339Nano)},
+		}},
+	})
+
+	// Race 2 finished, but only an hour ago, and race 5 is archived only
+	// once.
+	ids, err = repo.Archive(2*time.Hour, 10, audit)
+	if err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+	checkIds(t, ids, nil)
+
+	ids, err = repo.Archive(30*time.Minute, 10, audit)
+	if err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+	checkIds(t, ids, []int64{2})
+
+	races, _, err = repo.List(&racing.ListRacesRequest{Filter: &racing.ListRacesRequestFilter{IncludeArchived: true, Statuses: []racing.RaceStatus{racing.RaceStatus_ABANDONED}}})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	checkIds(t, raceIds(races), []int64{2})
+
+	if _, err := repo.Archive(0, 1, audit); !errors.Is(err, db.ErrInvalidArgument) {
+		t.Errorf("Archive with no age: err = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func testLocalDate(t *testing.T, repo db.RacesRepo) {
+	// Start times are given in the time zone of their meeting, or UTC if it
+	// has none.
+	for id, want := range map[int64]string{1: "2021-03-03T00:00:00+11:00", 3: "2021-03-03T01:00:00+11:00", 5: "2021-03-02T09:00:00Z"} {
+		race, err := repo.GetRaceById(id, nil, &field_mask.FieldMask{Paths: []string{"local_start_time"}})
+		if err != nil {
+			t.Fatalf("GetRaceById: %v", err)
+		}
+		if race.LocalStartTime != want {
+			t.Errorf("race %d local start time = %q, want %q", id, race.LocalStartTime, want)
+		}
+	}
+
+	// Melbourne's clocks went back an hour on 2021-04-04, a 25 hour day, and
+	// São Paulo's skipped midnight on 2018-11-04, which began at 01:00.
+	for _, tc := range []struct {
+		meetingId int64
+		start     time.Time
+		local     string
+		onDate    bool
+	}{
+		{1, time.Date(2021, 4, 3, 12, 30, 0, 0, time.UTC), "2021-04-03T23:30:00+11:00", false},
+		{1, time.Date(2021, 4, 3, 13, 30, 0, 0, time.UTC), "2021-04-04T00:30:00+11:00", true},
+		{1, time.Date(2021, 4, 4, 13, 30, 0, 0, time.UTC), "2021-04-04T23:30:00+10:00", true},
+		{1, time.Date(2021, 4, 4, 14, 30, 0, 0, time.UTC), "2021-04-05T00:30:00+10:00", false},
+		{4, time.Date(2018, 11, 4, 2, 30, 0, 0, time.UTC), "2018-11-03T23:30:00-03:00", false},
+		{4, time.Date(2018, 11, 4, 3, 30, 0, 0, time.UTC), "2018-11-04T01:30:00-02:00", true},
+	} {
+		start, _ := ptypes.TimestampProto(tc.start)
+		created, err := repo.Create(&racing.Race{MeetingId: tc.meetingId, Name: "Daylight Saving", Number: 1, AdvertisedStartTime: start}, audit)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if created.LocalStartTime != tc.local {
+			t.Errorf("race starting %s local start time = %q, want %q", tc.start, created.LocalStartTime, tc.local)
+		}
+
+		localDate := "2021-04-04"
+		if tc.meetingId == 4 {
+			localDate = "2018-11-04"
+		}
+		races, _, err := repo.List(&racing.ListRacesRequest{Filter: &racing.ListRacesRequestFilter{Ids: []int64{created.Id}, LocalDate: localDate}})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if onDate := len(races) == 1; onDate != tc.onDate {
+			t.Errorf("race starting %s on %s = %t, want %t", tc.start, localDate, onDate, tc.onDate)
+		}
+	}
+}
+
+// checkChanges compares changes, ignoring their IDs other than checking they
+// ascend.
+func checkChanges(t *testing.T, got, want []*racing.RaceChange) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("changes = %v, want %v", got, want)
+	}
+
+	for i := range got {
+		if i > 0 && got[i].Id <= got[i-1].Id {
+			t.Errorf("change %d has ID %d, not after %d", i, got[i].Id, got[i-1].Id)
+		}
+
+		g := proto.Clone(got[i]).(*racing.RaceChange)
+		g.Id = 0
+		if !proto.Equal(g, want[i]) {
+			t.Errorf("change %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+// at returns the instant d after Now.
+func at(d time.Duration) *timestamp.Timestamp {
+	ts, _ := ptypes.TimestampProto(Now.Add(d))
+	return ts
+}
+
+func raceIds(races []*racing.Race) []int64 {
+	ids := []int64{}
+	for _, race := range races {
+		ids = append(ids, race.Id)
+	}
+
+	return ids
+}
+
+func checkIds(t *testing.T, got, want []int64) {
+	t.Helper()
+
+	if len(got) == 0 && len(want) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ids = %v, want %v", got, want)
+	}
+}
+
+func checkRace(t *testing.T, got, want *racing.Race) {
+	t.Helper()
+
+	if got.Id != want.Id || got.MeetingId != want.MeetingId || got.Name != want.Name || got.Number != want.Number ||
+		got.Visible != want.Visible || !got.AdvertisedStartTime.AsTime().Equal(want.AdvertisedStartTime.AsTime()) ||
+		got.Status != want.Status || got.Etag != want.Etag || got.Category != want.Category ||
+		!proto.Equal(got.ArchivedTime, want.ArchivedTime) {
+		t.Errorf("race = %v, want %v", got, want)
+	}
+}
+
+func checkFieldError(t *testing.T, err error, field string) {
+	t.Helper()
+
+	var fieldErr *db.FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != field {
+		t.Errorf("err = %v, want a field error for %s", err, field)
+	}
+}
@@ -0,0 +1,55 @@
+package dbtest
+
+import (
+	"testing"
+	"time"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// Repos are the repositories of a single store, as the suites of those
+// reading and writing the races of another need them together.
+type Repos struct {
+	Races    db.RacesRepo
+	Meetings db.MeetingsRepo
+	Runners  db.RunnersRepo
+	Results  db.ResultsRepo
+	Prices   db.PricesRepo
+}
+
+// Fixture is the data the suites of the repositories other than races create
+// a store with: that of RacesFixture, and the runners of its races.
+type Fixture struct {
+	db.RacesFixture
+	Runners []*racing.Runner
+}
+
+// NewRepos creates the repositories under test over a single store, holding
+// exactly the data of fixture and deriving race status from clock.
+type NewRepos func(t *testing.T, clock db.Clock, fixture Fixture) Repos
+
+// RunnersFixture returns the data of RacesFixture along with its runners,
+// ordered by race and number:
+//
+//	race 1, OPEN        runners 2, 1 and 3, runner 3 scratched
+//	race 2, CLOSED      runners 6, 5, 4, 7 and 8, runner 8 scratched
+//	race 4, SUSPENDED   runner 9
+//	race 5, FINAL       runner 10
+func RunnersFixture() Fixture {
+	return Fixture{
+		RacesFixture: RacesFixture(),
+		Runners: []*racing.Runner{
+			{Id: 1, RaceId: 1, Number: 2, Name: "Black Caviar", Barrier: 4, Weight: 58.5},
+			{Id: 2, RaceId: 1, Number: 1, Name: "Winx", Barrier: 1, Weight: 57},
+			{Id: 3, RaceId: 1, Number: 3, Name: "Makybe Diva", Barrier: 2, Weight: 56, Scratched: true, ScratchedTime: at(-30 * time.Minute), ScratchingReason: "lame"},
+			{Id: 4, RaceId: 2, Number: 3, Name: "Phar Lap", Barrier: 3, Weight: 59},
+			{Id: 5, RaceId: 2, Number: 2, Name: "Kingston Town", Barrier: 5, Weight: 58},
+			{Id: 6, RaceId: 2, Number: 1, Name: "Sunline", Barrier: 2, Weight: 56.5},
+			{Id: 7, RaceId: 2, Number: 4, Name: "Might And Power", Barrier: 1, Weight: 57.5},
+			{Id: 8, RaceId: 2, Number: 5, Name: "Northerly", Barrier: 4, Weight: 58, Scratched: true, ScratchedTime: at(-time.Hour), ScratchingReason: "vet check"},
+			{Id: 9, RaceId: 4, Number: 1, Name: "Brett Lee", Barrier: 1, Weight: 32},
+			{Id: 10, RaceId: 5, Number: 1, Name: "Lazarus", Barrier: 1, Weight: 0},
+		},
+	}
+}
@@ -0,0 +1,198 @@
+package dbtest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// TestResultsRepo runs the conformance suite against the results repository
+// created by newRepos. Each test creates its own repositories.
+func TestResultsRepo(t *testing.T, newRepos NewRepos) {
+	clock := db.ClockFunc(func() time.Time { return Now })
+	repos := func(t *testing.T) Repos {
+		t.Helper()
+		return newRepos(t, clock, RunnersFixture())
+	}
+
+	t.Run("RecordValidation", func(t *testing.T) {
+		testRecordValidation(t, repos(t))
+	})
+	t.Run("Record", func(t *testing.T) {
+		testRecord(t, repos(t))
+	})
+}
+
+func testRecordValidation(t *testing.T, repos Repos) {
+	placings := []*racing.Placing{{RunnerId: 4, Position: 1}, {RunnerId: 5, Position: 2}, {RunnerId: 6, Position: 3}, {RunnerId: 7, Position: 4}}
+
+	for _, tc := range []struct {
+		name      string
+		placings  []*racing.Placing
+		dividends []*racing.Dividend
+		field     string
+	}{
+		{"no placings", nil, nil, "placings"},
+		{"unknown runner", []*racing.Placing{{RunnerId: 99, Position: 1}}, nil, "placings"},
+		{"runner of another race", []*racing.Placing{{RunnerId: 4, Position: 1}, {RunnerId: 1, Position: 2}}, nil, "placings"},
+		{"scratched runner", []*racing.Placing{{RunnerId: 4, Position: 1}, {RunnerId: 8, Position: 2}}, nil, "placings"},
+		{"duplicate placing", []*racing.Placing{{RunnerId: 4, Position: 1}, {RunnerId: 4, Position: 2}}, nil, "placings"},
+		{"position zero", []*racing.Placing{{RunnerId: 4, Position: 0}, {RunnerId: 5, Position: 1}}, nil, "placings"},
+		{"none first", []*racing.Placing{{RunnerId: 4, Position: 2}}, nil, "placings"},
+		{"win dividend for a loser", placings, []*racing.Dividend{{BetType: racing.Dividend_WIN, RunnerId: 5, AmountCents: 350}}, "dividends"},
+		{"place dividend for fourth", placings, []*racing.Dividend{{BetType: racing.Dividend_PLACE, RunnerId: 7, AmountCents: 350}}, "dividends"},
+		{"place dividend for an unplaced runner", placings[:2], []*racing.Dividend{{BetType: racing.Dividend_PLACE, RunnerId: 6, AmountCents: 350}}, "dividends"},
+		{"unknown bet type", placings, []*racing.Dividend{{RunnerId: 4, AmountCents: 350}}, "dividends"},
+		{"zero dividend", placings, []*racing.Dividend{{BetType: racing.Dividend_WIN, RunnerId: 4}}, "dividends"},
+		{"duplicate dividend", placings, []*racing.Dividend{
+			{BetType: racing.Dividend_PLACE, RunnerId: 4, AmountCents: 120},
+			{BetType: racing.Dividend_PLACE, RunnerId: 4, AmountCents: 130},
+		}, "dividends"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := repos.Results.Record(&racing.RecordResultRequest{RaceId: 2, Placings: tc.placings, Dividends: tc.dividends}, Now, audit)
+			checkFieldError(t, err, tc.field)
+		})
+	}
+
+	// Runners sharing a position dead heat, and are each paid on it.
+	deadHeat := &racing.RecordResultRequest{
+		RaceId:   2,
+		Placings: []*racing.Placing{{RunnerId: 4, Position: 1}, {RunnerId: 5, Position: 1}, {RunnerId: 6, Position: 3}},
+		Dividends: []*racing.Dividend{
+			{BetType: racing.Dividend_WIN, RunnerId: 4, AmountCents: 210},
+			{BetType: racing.Dividend_WIN, RunnerId: 5, AmountCents: 190},
+			{BetType: racing.Dividend_PLACE, RunnerId: 6, AmountCents: 140},
+		},
+	}
+	if _, err := repos.Results.Record(deadHeat, Now, audit); err != nil {
+		t.Errorf("Record of a dead heat: %v", err)
+	}
+
+	if _, err := repos.Results.Record(&racing.RecordResultRequest{RaceId: 1, Placings: []*racing.Placing{{RunnerId: 1, Position: 1}}}, Now, audit); !errors.Is(err, db.ErrFailedPrecondition) {
+		t.Errorf("Record of an open race: err = %v, want ErrFailedPrecondition", err)
+	}
+
+	if _, err := repos.Results.Record(&racing.RecordResultRequest{RaceId: 99, Placings: []*racing.Placing{{RunnerId: 1, Position: 1}}}, Now, audit); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("Record of a missing race: err = %v, want ErrNotFound", err)
+	}
+
+	if _, err := repos.Results.Get(1); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("Get of an unresulted race: err = %v, want ErrNotFound", err)
+	}
+}
+
+func testRecord(t *testing.T, repos Repos) {
+	interim := &racing.RecordResultRequest{
+		RaceId:   2,
+		Placings: []*racing.Placing{{RunnerId: 6, Position: 3}, {RunnerId: 4, Position: 1}, {RunnerId: 5, Position: 2}},
+		Dividends: []*racing.Dividend{
+			{BetType: racing.Dividend_PLACE, RunnerId: 4, AmountCents: 120},
+			{BetType: racing.Dividend_WIN, RunnerId: 4, AmountCents: 350},
+		},
+	}
+	given := proto.Clone(interim).(*racing.RecordResultRequest)
+
+	result, err := repos.Results.Record(interim, Now, audit)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	// The result is sorted, leaving the request as it was given.
+	if !proto.Equal(interim, given) {
+		t.Errorf("request = %v after recording, want it unchanged from %v", interim, given)
+	}
+	want := &racing.Result{
+		RaceId:   2,
+		Placings: []*racing.Placing{{RunnerId: 4, Position: 1}, {RunnerId: 5, Position: 2}, {RunnerId: 6, Position: 3}},
+		Dividends: []*racing.Dividend{
+			{BetType: racing.Dividend_WIN, RunnerId: 4, AmountCents: 350},
+			{BetType: racing.Dividend_PLACE, RunnerId: 4, AmountCents: 120},
+		},
+		RecordedTime: at(0),
+	}
+	checkResult(t, result, want)
+	checkRaceStatus(t, repos, 2, racing.RaceStatus_INTERIM, "2")
+
+	// Retrying the same interim result changes nothing, not even when it
+	// was recorded.
+	result, err = repos.Results.Record(interim, Now.Add(time.Minute), audit)
+	if err != nil {
+		t.Fatalf("Record retried: %v", err)
+	}
+	checkResult(t, result, want)
+	checkRaceStatus(t, repos, 2, racing.RaceStatus_INTERIM, "2")
+
+	// An interim result is corrected by recording another.
+	corrected := &racing.RecordResultRequest{
+		RaceId:   2,
+		Placings: []*racing.Placing{{RunnerId: 5, Position: 1}, {RunnerId: 4, Position: 2}},
+	}
+	if _, err := repos.Results.Record(corrected, Now.Add(2*time.Minute), audit); err != nil {
+		t.Fatalf("Record correction: %v", err)
+	}
+	result, err = repos.Results.Get(2)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	want = &racing.Result{RaceId: 2, Placings: corrected.Placings, RecordedTime: at(2 * time.Minute)}
+	checkResult(t, result, want)
+	checkRaceStatus(t, repos, 2, racing.RaceStatus_INTERIM, "")
+
+	final := proto.Clone(corrected).(*racing.RecordResultRequest)
+	final.Final = true
+	if _, err := repos.Results.Record(final, Now.Add(3*time.Minute), audit); err != nil {
+		t.Fatalf("Record final: %v", err)
+	}
+	checkRaceStatus(t, repos, 2, racing.RaceStatus_FINAL, "")
+
+	// A final result may be retried, but not changed.
+	if _, err := repos.Results.Record(final, Now.Add(4*time.Minute), audit); err != nil {
+		t.Errorf("Record final retried: %v", err)
+	}
+	for _, tc := range []struct {
+		name string
+		req  *racing.RecordResultRequest
+	}{
+		{"interim", corrected},
+		{"different final", &racing.RecordResultRequest{RaceId: 2, Placings: interim.Placings, Final: true}},
+	} {
+		if _, err := repos.Results.Record(tc.req, Now.Add(5*time.Minute), audit); !errors.Is(err, db.ErrFailedPrecondition) {
+			t.Errorf("Record %s over a final result: err = %v, want ErrFailedPrecondition", tc.name, err)
+		}
+	}
+
+	want = &racing.Result{RaceId: 2, Final: true, Placings: corrected.Placings, RecordedTime: at(3 * time.Minute)}
+	result, err = repos.Results.Get(2)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	checkResult(t, result, want)
+}
+
+func checkResult(t *testing.T, got, want *racing.Result) {
+	t.Helper()
+
+	if !proto.Equal(got, want) {
+		t.Errorf("result = %v, want %v", got, want)
+	}
+}
+
+// checkRaceStatus checks the status of a race, and its etag unless etag is
+// empty.
+func checkRaceStatus(t *testing.T, repos Repos, raceId int64, status racing.RaceStatus, etag string) {
+	t.Helper()
+
+	race, err := repos.Races.GetRaceById(raceId, nil, nil)
+	if err != nil {
+		t.Fatalf("GetRaceById: %v", err)
+	}
+	if race.Status != status || (etag != "" && race.Etag != etag) {
+		t.Errorf("race = %v, want it %s at etag %q", race, status, etag)
+	}
+}
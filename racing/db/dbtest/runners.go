@@ -0,0 +1,183 @@
+package dbtest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// TestRunnersRepo runs the conformance suite against the runners repository
+// created by newRepos. Each test creates its own repositories.
+func TestRunnersRepo(t *testing.T, newRepos NewRepos) {
+	clock := db.ClockFunc(func() time.Time { return Now })
+	repos := func(t *testing.T) Repos {
+		t.Helper()
+		return newRepos(t, clock, RunnersFixture())
+	}
+
+	t.Run("List", func(t *testing.T) {
+		testListRunners(t, repos(t))
+	})
+	t.Run("ListPaging", func(t *testing.T) {
+		testListRunnersPaging(t, repos(t))
+	})
+	t.Run("Get", func(t *testing.T) {
+		testGetRunner(t, repos(t))
+	})
+	t.Run("Scratch", func(t *testing.T) {
+		testScratch(t, repos(t))
+	})
+}
+
+func testListRunners(t *testing.T, repos Repos) {
+	for _, tc := range []struct {
+		name   string
+		filter *racing.ListRunnersRequestFilter
+		want   []int64
+	}{
+		{"none", nil, []int64{2, 1, 3, 6, 5, 4, 7, 8, 9, 10}},
+		{"race ids", &racing.ListRunnersRequestFilter{RaceIds: []int64{4, 1, 99}}, []int64{2, 1, 3, 9}},
+		{"exclude scratched", &racing.ListRunnersRequestFilter{RaceIds: []int64{1, 2}, ExcludeScratched: true}, []int64{2, 1, 6, 5, 4, 7}},
+		{"no match", &racing.ListRunnersRequestFilter{RaceIds: []int64{3}}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runners, token, err := repos.Runners.List(&racing.ListRunnersRequest{Filter: tc.filter})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if token != "" {
+				t.Errorf("next page token = %q, want none", token)
+			}
+			checkIds(t, runnerIds(runners), tc.want)
+		})
+	}
+
+	for _, tc := range []struct {
+		name  string
+		req   *racing.ListRunnersRequest
+		field string
+	}{
+		{"negative page size", &racing.ListRunnersRequest{PageSize: -1}, "page_size"},
+		{"malformed page token", &racing.ListRunnersRequest{PageToken: "!"}, "page_token"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := repos.Runners.List(tc.req)
+			checkFieldError(t, err, tc.field)
+		})
+	}
+
+	runners, err := repos.Runners.ListByRace(2)
+	if err != nil {
+		t.Fatalf("ListByRace: %v", err)
+	}
+	checkIds(t, runnerIds(runners), []int64{6, 5, 4, 7, 8})
+}
+
+func testListRunnersPaging(t *testing.T, repos Repos) {
+	// Pages of three end within races 1 and 2, and on the last runner of
+	// race 2.
+	req := &racing.ListRunnersRequest{
+		Filter:   &racing.ListRunnersRequestFilter{RaceIds: []int64{1, 2, 4}},
+		PageSize: 3,
+	}
+
+	var (
+		ids   []int64
+		pages int
+	)
+	for {
+		runners, token, err := repos.Runners.List(req)
+		if err != nil {
+			t.Fatalf("List page %d: %v", pages, err)
+		}
+		ids = append(ids, runnerIds(runners)...)
+		pages++
+
+		if token == "" {
+			break
+		}
+		req.PageToken = token
+	}
+
+	checkIds(t, ids, []int64{2, 1, 3, 6, 5, 4, 7, 8, 9})
+	if pages != 3 {
+		t.Errorf("pages = %d, want 3", pages)
+	}
+
+	// A token is only valid for the request it was issued for.
+	_, token, err := repos.Runners.List(&racing.ListRunnersRequest{PageSize: 1})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	_, _, err = repos.Runners.List(&racing.ListRunnersRequest{PageSize: 1, PageToken: token, Filter: &racing.ListRunnersRequestFilter{ExcludeScratched: true}})
+	checkFieldError(t, err, "page_token")
+}
+
+func testGetRunner(t *testing.T, repos Repos) {
+	runner, err := repos.Runners.Get(3)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if want := RunnersFixture().Runners[2]; !proto.Equal(runner, want) {
+		t.Errorf("runner = %v, want %v", runner, want)
+	}
+
+	if _, err := repos.Runners.Get(99); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("Get of a missing runner: err = %v, want ErrNotFound", err)
+	}
+}
+
+func testScratch(t *testing.T, repos Repos) {
+	runner, err := repos.Runners.Scratch(1, "lame", Now, audit)
+	if err != nil {
+		t.Fatalf("Scratch: %v", err)
+	}
+	want := proto.Clone(RunnersFixture().Runners[0]).(*racing.Runner)
+	want.Scratched, want.ScratchedTime, want.ScratchingReason = true, at(0), "lame"
+	if !proto.Equal(runner, want) {
+		t.Errorf("runner = %v, want %v", runner, want)
+	}
+
+	// A runner is scratched only once, keeping when and why it first was.
+	if _, err := repos.Runners.Scratch(1, "vet check", Now.Add(time.Minute), audit); !errors.Is(err, db.ErrFailedPrecondition) {
+		t.Errorf("Scratch of a scratched runner: err = %v, want ErrFailedPrecondition", err)
+	}
+	if runner, err = repos.Runners.Get(1); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !proto.Equal(runner, want) {
+		t.Errorf("runner = %v, want %v", runner, want)
+	}
+
+	// Runners cannot be scratched once their race is resulted, whether
+	// interim or final.
+	if _, err := repos.Results.Record(&racing.RecordResultRequest{RaceId: 2, Placings: []*racing.Placing{{RunnerId: 6, Position: 1}}}, Now, audit); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	for _, runnerId := range []int64{7, 10} {
+		if _, err := repos.Runners.Scratch(runnerId, "lame", Now, audit); !errors.Is(err, db.ErrFailedPrecondition) {
+			t.Errorf("Scratch of runner %d of a resulted race: err = %v, want ErrFailedPrecondition", runnerId, err)
+		}
+	}
+
+	if _, err := repos.Runners.Scratch(99, "lame", Now, audit); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("Scratch of a missing runner: err = %v, want ErrNotFound", err)
+	}
+
+	_, err = repos.Runners.Scratch(2, " ", Now, audit)
+	checkFieldError(t, err, "reason")
+}
+
+func runnerIds(runners []*racing.Runner) []int64 {
+	ids := []int64{}
+	for _, runner := range runners {
+		ids = append(ids, runner.Id)
+	}
+
+	return ids
+}
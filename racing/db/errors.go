@@ -0,0 +1,60 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidArgument is wrapped by errors caused by a caller supplying a
+	// filter, ordering or other request value the repository cannot honour.
+	ErrInvalidArgument = errors.New("invalid argument")
+
+	// ErrNotFound is wrapped by errors caused by a requested resource not
+	// existing.
+	ErrNotFound = errors.New("not found")
+
+	// ErrFailedPrecondition is wrapped by errors caused by a resource not
+	// being in a state that permits the requested change.
+	ErrFailedPrecondition = errors.New("failed precondition")
+
+	// ErrAborted is wrapped by errors caused by a write conflicting with a
+	// concurrent change, such as a write given a stale etag.
+	ErrAborted = errors.New("aborted")
+)
+
+// FieldError is an ErrInvalidArgument caused by the value of a single field
+// of a request.
+type FieldError struct {
+	// Field is the path of the field within the request, such as
+	// filter.statuses.
+	Field string
+	// Description says what is wrong with the field's value.
+	Description string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrInvalidArgument, e.Field, e.Description)
+}
+
+// Unwrap makes a FieldError match ErrInvalidArgument.
+func (e *FieldError) Unwrap() error {
+	return ErrInvalidArgument
+}
+
+// invalidField returns a FieldError for field, its description formatted
+// from format and args.
+func invalidField(field, format string, args ...interface{}) error {
+	return &FieldError{Field: field, Description: fmt.Sprintf(format, args...)}
+}
+
+// nestField places the field of a FieldError within parent, for errors from
+// validating a message nested in a request. Other errors are returned as is.
+func nestField(parent string, err error) error {
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) {
+		return err
+	}
+
+	return &FieldError{Field: parent + "." + fieldErr.Field, Description: fieldErr.Description}
+}
@@ -0,0 +1,282 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/shared/page"
+)
+
+// Audit says who is making a change and why, to be recorded in the history
+// of each race it changes.
+type Audit struct {
+	// Actor names who is making the change, such as a user or a command.
+	Actor string
+	// Reason says why the change is being made, and may be empty.
+	Reason string
+}
+
+// raceHistoryFields are the fields of a race recorded in its history, in the
+// order they are declared.
+var raceHistoryFields = []string{"meeting_id", "name", "number", "visible", "advertised_start_time", "status", "category", "archived_time"}
+
+// fieldChange is the stored form of a racing.FieldChange.
+type fieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// raceFieldValues renders the recorded fields of a race as they are written
+// in JSON, unquoted. A nil race has no values.
+func raceFieldValues(race *racing.Race) map[string]string {
+	if race == nil {
+		return nil
+	}
+
+	values := map[string]string{
+		"meeting_id": strconv.FormatInt(race.MeetingId, 10),
+		"name":       race.Name,
+		"number":     strconv.FormatInt(race.Number, 10),
+		"visible":    strconv.FormatBool(race.Visible),
+		"status":     race.Status.String(),
+		"category":   race.Category.String(),
+	}
+	if race.AdvertisedStartTime != nil {
+		values["advertised_start_time"] = race.AdvertisedStartTime.AsTime().UTC().Format(time.RFC3339Nano)
+	}
+	if race.ArchivedTime != nil {
+		values["archived_time"] = race.ArchivedTime.AsTime().UTC().Format(time.RFC3339Nano)
+	}
+
+	return values
+}
+
+// newRaceChange describes a change to a race from before to after, either of
+// which is nil where the race does not exist, made at the given time. Only
+// the fields whose values differ are recorded.
+func newRaceChange(before, after *racing.Race, audit Audit, at time.Time) (*racing.RaceChange, error) {
+	changeTime, err := ptypes.TimestampProto(at)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &racing.RaceChange{
+		Action:     racing.RaceChange_UPDATED,
+		Actor:      audit.Actor,
+		Reason:     audit.Reason,
+		ChangeTime: changeTime,
+	}
+
+	switch {
+	case before == nil:
+		change.Action = racing.RaceChange_CREATED
+		change.RaceId, change.Etag = after.Id, after.Etag
+	case after == nil:
+		change.Action = racing.RaceChange_DELETED
+		change.RaceId, change.Etag = before.Id, before.Etag
+	default:
+		change.RaceId, change.Etag = after.Id, after.Etag
+	}
+
+	old, new := raceFieldValues(before), raceFieldValues(after)
+	for _, field := range raceHistoryFields {
+		if old[field] != new[field] {
+			change.Fields = append(change.Fields, &racing.FieldChange{Field: field, OldValue: old[field], NewValue: new[field]})
+		}
+	}
+
+	return change, nil
+}
+
+// raceChangeArgs returns the values of a change to insert with
+// racesHistoryInsert.
+func raceChangeArgs(change *racing.RaceChange) ([]interface{}, error) {
+	fields := make([]fieldChange, 0, len(change.Fields))
+	for _, field := range change.Fields {
+		fields = append(fields, fieldChange{Field: field.Field, Old: field.OldValue, New: field.NewValue})
+	}
+
+	changes, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := strconv.ParseInt(change.Etag, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{
+		change.RaceId,
+		change.Action.String(),
+		change.Actor,
+		change.Reason,
+		formatTime(change.ChangeTime.AsTime()),
+		version,
+		string(changes),
+	}, nil
+}
+
+// recordRaceChange appends the change from before to after to the race's
+// history, within the transaction making it.
+func recordRaceChange(tx *sql.Tx, before, after *racing.Race, audit Audit, at time.Time) error {
+	change, err := newRaceChange(before, after, audit, at)
+	if err != nil {
+		return err
+	}
+
+	args, err := raceChangeArgs(change)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(getRaceQueries()[racesHistoryInsert], args...)
+	return err
+}
+
+// recordRaceFields appends a change to a race's history setting fields of
+// what the race holds, such as its runners and market, which leave the race
+// itself, and so its etag, as it was.
+func recordRaceFields(tx *sql.Tx, raceId int64, fields []*racing.FieldChange, audit Audit, at time.Time) error {
+	var version int64
+	err := tx.QueryRow(`SELECT version FROM races WHERE id = ?`, raceId).Scan(&version)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: race %d", ErrNotFound, raceId)
+	}
+	if err != nil {
+		return err
+	}
+
+	changeTime, err := ptypes.TimestampProto(at)
+	if err != nil {
+		return err
+	}
+
+	args, err := raceChangeArgs(&racing.RaceChange{
+		RaceId:     raceId,
+		Action:     racing.RaceChange_UPDATED,
+		Actor:      audit.Actor,
+		Reason:     audit.Reason,
+		ChangeTime: changeTime,
+		Etag:       strconv.FormatInt(version, 10),
+		Fields:     fields,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(getRaceQueries()[racesHistoryInsert], args...)
+	return err
+}
+
+// runnerField names a field of one of a race's runners, as it is recorded in
+// the race's history.
+func runnerField(runnerId int64, field string) string {
+	return fmt.Sprintf("runners.%d.%s", runnerId, field)
+}
+
+// historyPage validates a request for a race's history, returning the page
+// size, the checksum page tokens are made with, and the ID of the last change
+// on the previous page, or 0 for the first page.
+func historyPage(in *racing.ListRaceHistoryRequest) (int, uint32, int64, error) {
+	if in.GetRaceId() < 1 {
+		return 0, 0, 0, invalidField("race_id", "must be positive")
+	}
+
+	size, err := pageSize(in.GetPageSize())
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	// Changes are always ordered by ID, so a page token records the ID of
+	// the last change returned.
+	checksum := pageChecksum(&racing.ListRaceHistoryRequest{RaceId: in.GetRaceId()}, nil)
+	if in.GetPageToken() == "" {
+		return size, checksum, 0, nil
+	}
+
+	keys, err := decodePageToken(in.GetPageToken(), checksum)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	var after int64
+	if err := page.DecodeKeys(keys, &after); err != nil {
+		return 0, 0, 0, invalidPageToken(err)
+	}
+
+	return size, checksum, after, nil
+}
+
+// historyNextPage trims changes, which hold one more than a page if there is
+// a next page, to size, returning the token for the next page.
+func historyNextPage(changes []*racing.RaceChange, size int, checksum uint32) ([]*racing.RaceChange, string, error) {
+	if len(changes) <= size {
+		return changes, "", nil
+	}
+
+	changes = changes[:size]
+	token, err := page.EncodeToken(checksum, []interface{}{changes[size-1].Id})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return changes, token, nil
+}
+
+func (r *racesRepo) History(in *racing.ListRaceHistoryRequest) ([]*racing.RaceChange, string, error) {
+	size, checksum, after, err := historyPage(in)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Fetch one more change than requested to learn whether a next page
+	// exists.
+	rows, err := r.db.Query(getRaceQueries()[racesHistory], in.RaceId, after, size+1)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var changes []*racing.RaceChange
+	for rows.Next() {
+		var (
+			change     racing.RaceChange
+			action     string
+			changeTime time.Time
+			version    int64
+			stored     string
+		)
+		if err := rows.Scan(&change.Id, &change.RaceId, &action, &change.Actor, &change.Reason, &changeTime, &version, &stored); err != nil {
+			return nil, "", err
+		}
+
+		change.Action = racing.RaceChange_Action(racing.RaceChange_Action_value[action])
+		change.Etag = strconv.FormatInt(version, 10)
+		if change.ChangeTime, err = ptypes.TimestampProto(changeTime); err != nil {
+			return nil, "", err
+		}
+
+		var fields []fieldChange
+		if err := json.Unmarshal([]byte(stored), &fields); err != nil {
+			return nil, "", err
+		}
+		for _, field := range fields {
+			change.Fields = append(change.Fields, &racing.FieldChange{Field: field.Field, OldValue: field.Old, NewValue: field.New})
+		}
+
+		changes = append(changes, &change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	return historyNextPage(changes, size, checksum)
+}
@@ -0,0 +1,217 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/shared/page"
+)
+
+// meetingDateLayout is the layout meeting dates are stored and exchanged in.
+const meetingDateLayout = "2006-01-02"
+
+// MeetingsRepo provides repository access to meetings.
+type MeetingsRepo interface {
+	// Init will initialise our meetings repository.
+	Init() error
+
+	// List will return a page of meetings, along with a token for the next
+	// page, or an empty token if there are no further meetings.
+	List(in *racing.ListMeetingsRequest) ([]*racing.Meeting, string, error)
+
+	// Get will return a single meeting, or ErrNotFound if none match the ID.
+	Get(meetingId int64) (*racing.Meeting, error)
+}
+
+type meetingsRepo struct {
+	db *sql.DB
+}
+
+// NewMeetingsRepo creates a new meetings repository.
+func NewMeetingsRepo(db *sql.DB) MeetingsRepo {
+	return &meetingsRepo{db: db}
+}
+
+// Init does nothing, as dummy meetings are only seeded by the seed command.
+// The meetings table is created by migrations.
+func (r *meetingsRepo) Init() error {
+	return nil
+}
+
+func (r *meetingsRepo) Get(meetingId int64) (*racing.Meeting, error) {
+	rows, err := r.db.Query(getMeetingQueries()[meetingsList]+" WHERE id = ?", meetingId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	meetings, err := r.scanMeetings(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(meetings) == 0 {
+		return nil, fmt.Errorf("%w: meeting %d", ErrNotFound, meetingId)
+	}
+
+	return meetings[0], nil
+}
+
+func (r *meetingsRepo) List(in *racing.ListMeetingsRequest) ([]*racing.Meeting, string, error) {
+	size, err := pageSize(in.GetPageSize())
+	if err != nil {
+		return nil, "", err
+	}
+
+	clauses, args, err := r.applyFilter(in.GetFilter())
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Meetings are always ordered by date then ID, so a page token records
+	// the date and ID of the last meeting returned.
+	checksum := pageChecksum(in.GetFilter(), nil)
+	if in.GetPageToken() != "" {
+		keys, err := decodePageToken(in.GetPageToken(), checksum)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var (
+			date string
+			id   int64
+		)
+		if err := page.DecodeKeys(keys, &date, &id); err != nil {
+			return nil, "", invalidPageToken(err)
+		}
+
+		clauses = append(clauses, "(date > ? OR (date = ? AND id > ?))")
+		args = append(args, date, date, id)
+	}
+
+	query := getMeetingQueries()[meetingsList]
+	if len(clauses) != 0 {
+		query += " WHERE " + strings.Join(clauses, " AND ")
+	}
+
+	// Fetch one more meeting than requested to learn whether a next page exists.
+	query += " ORDER BY date ASC, id ASC LIMIT ?"
+	args = append(args, size+1)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	meetings, err := r.scanMeetings(rows)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextPageToken string
+	if len(meetings) > size {
+		meetings = meetings[:size]
+
+		last := meetings[size-1]
+		nextPageToken, err = page.EncodeToken(checksum, []interface{}{last.Date, last.Id})
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return meetings, nextPageToken, nil
+}
+
+// applyFilter converts a filter into SQL WHERE conditions and their args.
+func (r *meetingsRepo) applyFilter(filter *racing.ListMeetingsRequestFilter) ([]string, []interface{}, error) {
+	var (
+		clauses []string
+		args    []interface{}
+	)
+
+	if filter == nil {
+		return clauses, args, nil
+	}
+
+	if len(filter.Ids) > 0 {
+		clauses = append(clauses, "id IN ("+strings.Repeat("?,", len(filter.Ids)-1)+"?)")
+
+		for _, id := range filter.Ids {
+			args = append(args, id)
+		}
+	}
+
+	if len(filter.Countries) > 0 {
+		clauses = append(clauses, "country IN ("+strings.Repeat("?,", len(filter.Countries)-1)+"?)")
+
+		for _, country := range filter.Countries {
+			args = append(args, strings.ToUpper(country))
+		}
+	}
+
+	if len(filter.Categories) > 0 {
+		clauses = append(clauses, "category IN ("+strings.Repeat("?,", len(filter.Categories)-1)+"?)")
+
+		for _, category := range filter.Categories {
+			if !validRaceCategory(category) {
+				return nil, nil, invalidField("filter.categories", "unknown category %q", category)
+			}
+			args = append(args, category.String())
+		}
+	}
+
+	if filter.Date != "" {
+		if _, err := time.Parse(meetingDateLayout, filter.Date); err != nil {
+			return nil, nil, invalidField("filter.date", "must be formatted YYYY-MM-DD")
+		}
+
+		clauses = append(clauses, "date = ?")
+		args = append(args, filter.Date)
+	}
+
+	if len(filter.Statuses) > 0 {
+		clauses = append(clauses, "status IN ("+strings.Repeat("?,", len(filter.Statuses)-1)+"?)")
+
+		for _, status := range filter.Statuses {
+			if _, ok := racing.Meeting_Status_name[int32(status)]; !ok || status == racing.Meeting_STATUS_UNSPECIFIED {
+				return nil, nil, invalidField("filter.statuses", "unknown status %q", status)
+			}
+			args = append(args, status.String())
+		}
+	}
+
+	return clauses, args, nil
+}
+
+func (r *meetingsRepo) scanMeetings(rows *sql.Rows) ([]*racing.Meeting, error) {
+	var meetings []*racing.Meeting
+
+	for rows.Next() {
+		var (
+			meeting  racing.Meeting
+			category string
+			status   string
+		)
+
+		if err := rows.Scan(&meeting.Id, &meeting.Name, &meeting.Country, &meeting.Date, &category, &status, &meeting.TimeZone); err != nil {
+			return nil, err
+		}
+
+		meeting.Category = racing.RaceCategory(racing.RaceCategory_value[category])
+		meeting.Status = racing.Meeting_Status(racing.Meeting_Status_value[status])
+
+		meetings = append(meetings, &meeting)
+	}
+
+	return meetings, rows.Err()
+}
+
+// validRaceCategory reports whether category is a known, specified category.
+func validRaceCategory(category racing.RaceCategory) bool {
+	_, ok := racing.RaceCategory_name[int32(category)]
+	return ok && category != racing.RaceCategory_CATEGORY_UNSPECIFIED
+}
@@ -0,0 +1,1088 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/genproto/protobuf/field_mask"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/shared/page"
+	"git.neds.sh/matty/entain/shared/search"
+)
+
+// RacesFixture is the data an in-memory races repository is created with.
+type RacesFixture struct {
+	// Meetings are the meetings races may belong to. Races are validated
+	// against them, and those created without a category take their
+	// meeting's.
+	Meetings []*racing.Meeting
+	// Races are stored as given, except that a race without a status is
+	// OPEN, and one without an etag is at its first version.
+	Races []*racing.Race
+	// ResultedRaceIds are the IDs of the races with a recorded result.
+	ResultedRaceIds []int64
+}
+
+// memoryRace is a race as the in-memory repository stores it, mirroring a
+// row of the races table.
+type memoryRace struct {
+	id              int64
+	meetingId       int64
+	name            string
+	number          int64
+	visible         bool
+	advertisedStart time.Time
+	// status is the persisted status, from which the status read is derived.
+	status   racing.RaceStatus
+	version  int64
+	category racing.RaceCategory
+	// archived is when the race was archived, or zero if it is not.
+	archived time.Time
+}
+
+type memoryRacesRepo struct {
+	clock Clock
+
+	mu       sync.Mutex
+	races    map[int64]*memoryRace
+	meetings map[int64]racing.RaceCategory
+	// timeZones are the time zones of the meetings that have one.
+	timeZones map[int64]string
+	resulted  map[int64]bool
+	history   []*racing.RaceChange
+}
+
+// NewMemoryRacesRepo creates a races repository holding fixture in memory,
+// deriving race status from clock. It behaves as the SQLite repository does,
+// so that it can stand in for it in tests, except that there are no runners
+// or prices for it to delete along with a race.
+func NewMemoryRacesRepo(clock Clock, fixture RacesFixture) RacesRepo {
+	r := &memoryRacesRepo{
+		clock:     clock,
+		races:     make(map[int64]*memoryRace),
+		meetings:  make(map[int64]racing.RaceCategory),
+		timeZones: make(map[int64]string),
+		resulted:  make(map[int64]bool),
+	}
+
+	for _, meeting := range fixture.Meetings {
+		r.meetings[meeting.Id] = meeting.Category
+		if meeting.TimeZone != "" {
+			r.timeZones[meeting.Id] = meeting.TimeZone
+		}
+	}
+
+	for _, race := range fixture.Races {
+		row := &memoryRace{
+			id:              race.Id,
+			meetingId:       race.MeetingId,
+			name:            race.Name,
+			number:          race.Number,
+			visible:         race.Visible,
+			advertisedStart: race.AdvertisedStartTime.AsTime(),
+			status:          race.Status,
+			version:         1,
+			category:        race.Category,
+		}
+		if row.status == racing.RaceStatus_STATUS_UNSPECIFIED {
+			row.status = racing.RaceStatus_OPEN
+		}
+		if version, err := strconv.ParseInt(race.Etag, 10, 64); err == nil {
+			row.version = version
+		}
+		if race.ArchivedTime != nil {
+			row.archived = race.ArchivedTime.AsTime()
+		}
+
+		r.races[row.id] = row
+	}
+
+	for _, id := range fixture.ResultedRaceIds {
+		r.resulted[id] = true
+	}
+
+	return r
+}
+
+// Init does nothing, as the repository holds only its fixture.
+func (r *memoryRacesRepo) Init() error {
+	return nil
+}
+
+func (r *memoryRacesRepo) GetRaceById(raceId int64, asOf *timestamp.Timestamp, readMask *field_mask.FieldMask) (*racing.Race, error) {
+	now, err := statusInstant(r.clock, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := readColumns(readMask, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	row, err := r.get(raceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.race(row, now, columns), nil
+}
+
+func (r *memoryRacesRepo) BatchGet(in *racing.BatchGetRacesRequest) ([]*racing.Race, []int64, error) {
+	if len(in.RaceIds) > maxBatchGet {
+		return nil, nil, invalidField("race_ids", "at most %d races may be fetched at once", maxBatchGet)
+	}
+
+	if len(in.RaceIds) == 0 {
+		return nil, nil, nil
+	}
+
+	now, err := statusInstant(r.clock, in.GetAsOf())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		races   []*racing.Race
+		missing []int64
+		seen    = make(map[int64]bool)
+	)
+	for _, id := range in.RaceIds {
+		if row, ok := r.races[id]; ok {
+			races = append(races, r.race(row, now, raceColumns))
+			continue
+		}
+
+		if !seen[id] {
+			missing = append(missing, id)
+			seen[id] = true
+		}
+	}
+
+	if len(missing) > 0 && !in.AllowMissing {
+		return nil, nil, fmt.Errorf("%w: races %s", ErrNotFound, joinIds(missing))
+	}
+
+	return races, missing, nil
+}
+
+func (r *memoryRacesRepo) TransitionRace(raceId int64, status racing.RaceStatus, etag string, audit Audit) (*racing.Race, error) {
+	if !validRaceStatus(status) {
+		return nil, invalidField("status", "unknown status %q", status)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	row, err := r.get(raceId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Repeating the current status is allowed and changes nothing, so
+	// transitions can be retried, though not with a stale etag.
+	race := r.race(row, r.clock.Now(), raceColumns)
+	if race.Status == status {
+		if err := row.checkEtag(etag); err != nil {
+			return nil, err
+		}
+		return race, nil
+	}
+
+	if !canTransition(race.Status, status) {
+		return nil, fmt.Errorf("%w: race %d cannot move from %s to %s", ErrFailedPrecondition, raceId, race.Status, status)
+	}
+
+	if err := row.checkEtag(etag); err != nil {
+		return nil, err
+	}
+
+	stored := r.storedRace(row)
+	row.status = status
+	row.version++
+
+	if err := r.record(stored, r.storedRace(row), audit); err != nil {
+		return nil, err
+	}
+
+	return r.race(row, r.clock.Now(), raceColumns), nil
+}
+
+func (r *memoryRacesRepo) NextToJump(in *racing.ListNextToJumpRequest) ([]*racing.NextToJump, error) {
+	now, err := statusInstant(r.clock, in.GetAsOf())
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(in.Limit)
+	switch {
+	case limit < 0:
+		return nil, invalidField("limit", "must not be negative")
+	case limit == 0:
+		limit = defaultNextToJump
+	case limit > maxNextToJump:
+		limit = maxNextToJump
+	}
+
+	if in.MaxPerMeeting < 0 {
+		return nil, invalidField("max_per_meeting", "must not be negative")
+	}
+
+	categories := make(map[racing.RaceCategory]bool)
+	for _, category := range in.Categories {
+		if !validRaceCategory(category) {
+			return nil, invalidField("categories", "unknown category %q", category)
+		}
+		categories[category] = true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Races are open up to and including their advertised start time, and
+	// filtered on category before being ranked within their meeting.
+	var open []*memoryRace
+	for _, row := range r.races {
+		if len(categories) > 0 && !categories[row.category] {
+			continue
+		}
+		if row.status == racing.RaceStatus_OPEN && row.visible && instantMillis(row.advertisedStart) >= instantMillis(now) {
+			open = append(open, row)
+		}
+	}
+	sortRaces(open, []orderTerm{
+		{raceOrderField: raceOrderFields[racing.RaceOrder_ADVERTISED_START_TIME]},
+		{raceOrderField: raceOrderFields[racing.RaceOrder_ID]},
+	})
+
+	var (
+		next  []*racing.NextToJump
+		ranks = make(map[int64]int32)
+	)
+	for _, row := range open {
+		ranks[row.meetingId]++
+
+		if in.MaxPerMeeting > 0 && ranks[row.meetingId] > in.MaxPerMeeting {
+			continue
+		}
+		if len(next) == limit {
+			break
+		}
+
+		race := r.race(row, now, raceColumns)
+		next = append(next, &racing.NextToJump{
+			Race:          race,
+			SecondsToJump: int64(race.AdvertisedStartTime.AsTime().Sub(now) / time.Second),
+		})
+	}
+
+	return next, nil
+}
+
+func (r *memoryRacesRepo) Create(race *racing.Race, audit Audit) (*racing.Race, error) {
+	if race == nil {
+		return nil, invalidField("race", "is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	values, err := r.validateNew(race)
+	if err != nil {
+		return nil, nestField("race", err)
+	}
+
+	// IDs are assigned as SQLite assigns row IDs, one past the largest.
+	var id int64
+	for existing := range r.races {
+		if existing > id {
+			id = existing
+		}
+	}
+
+	row := &memoryRace{id: id + 1, status: racing.RaceStatus_OPEN, version: 1}
+	row.set(values)
+	r.races[row.id] = row
+
+	if err := r.record(nil, r.storedRace(row), audit); err != nil {
+		return nil, err
+	}
+
+	return r.race(row, r.clock.Now(), raceColumns), nil
+}
+
+func (r *memoryRacesRepo) Update(race *racing.Race, paths []string, audit Audit) (*racing.Race, error) {
+	if race == nil {
+		return nil, invalidField("race", "is required")
+	}
+
+	if len(paths) == 0 {
+		return nil, invalidField("update_mask", "is required")
+	}
+
+	for _, path := range paths {
+		if _, ok := raceUpdateColumns[path]; !ok {
+			return nil, invalidField("update_mask", "field %q cannot be updated", path)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	row, err := r.get(race.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.validate(race, paths)
+	if err != nil {
+		return nil, nestField("race", err)
+	}
+
+	if err := row.checkEtag(race.Etag); err != nil {
+		return nil, err
+	}
+
+	existing := r.storedRace(row)
+	row.set(values)
+	row.version++
+
+	if err := r.record(existing, r.storedRace(row), audit); err != nil {
+		return nil, err
+	}
+
+	return r.race(row, r.clock.Now(), raceColumns), nil
+}
+
+func (r *memoryRacesRepo) Import(races []*racing.Race, dryRun bool, audit Audit) (*ImportResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Races are written to a copy, which replaces the stored races only if
+	// the whole batch is committed.
+	staged := make(map[int64]*memoryRace, len(r.races))
+	for id, row := range r.races {
+		copied := *row
+		staged[id] = &copied
+	}
+
+	// Changes are likewise recorded only once the batch is committed.
+	type change struct{ before, after *racing.Race }
+
+	var (
+		result  = &ImportResult{Errors: make(map[int]error)}
+		seen    = make(map[int64]bool)
+		changes []change
+	)
+
+	for i, race := range races {
+		if race.GetId() < 1 {
+			result.Errors[i] = invalidField("id", "must be positive")
+			continue
+		}
+
+		if seen[race.Id] {
+			result.Errors[i] = invalidField("id", "race %d is imported more than once", race.Id)
+			continue
+		}
+		seen[race.Id] = true
+
+		values, err := r.validateNew(race)
+		if errors.Is(err, ErrInvalidArgument) {
+			result.Errors[i] = err
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row, exists := staged[race.Id]
+		switch {
+		case !exists:
+			row = &memoryRace{id: race.Id, status: racing.RaceStatus_OPEN, version: 1}
+			row.set(values)
+			staged[row.id] = row
+			result.Created++
+			changes = append(changes, change{after: r.storedRace(row)})
+		case row.differs(values):
+			existing := r.storedRace(row)
+			row.set(values)
+			row.version++
+			result.Updated++
+			changes = append(changes, change{before: existing, after: r.storedRace(row)})
+		default:
+			result.Unchanged++
+		}
+	}
+
+	if dryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	r.races = staged
+	result.Committed = true
+
+	for _, c := range changes {
+		if err := r.record(c.before, c.after, audit); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (r *memoryRacesRepo) Delete(raceId int64, etag string, audit Audit) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	row, err := r.get(raceId)
+	if err != nil {
+		return err
+	}
+
+	// Results are kept as a record of what was paid out.
+	if r.resulted[raceId] {
+		return fmt.Errorf("%w: race %d has a recorded result", ErrFailedPrecondition, raceId)
+	}
+
+	if err := row.checkEtag(etag); err != nil {
+		return err
+	}
+
+	delete(r.races, raceId)
+
+	return r.record(r.storedRace(row), nil, audit)
+}
+
+func (r *memoryRacesRepo) Archive(olderThan time.Duration, limit int, audit Audit) ([]int64, error) {
+	if err := validateArchive(olderThan, limit); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.clock.Now()
+	cutoff := instantMillis(now.Add(-olderThan))
+
+	var finished []*memoryRace
+	for _, row := range r.races {
+		if row.archived.IsZero() &&
+			(row.status == racing.RaceStatus_FINAL || row.status == racing.RaceStatus_ABANDONED) &&
+			instantMillis(row.advertisedStart) < cutoff {
+			finished = append(finished, row)
+		}
+	}
+	sortRaces(finished, []orderTerm{
+		{raceOrderField: raceOrderFields[racing.RaceOrder_ADVERTISED_START_TIME]},
+		{raceOrderField: raceOrderFields[racing.RaceOrder_ID]},
+	})
+	if len(finished) > limit {
+		finished = finished[:limit]
+	}
+
+	ids := make([]int64, 0, len(finished))
+	for _, row := range finished {
+		race := r.storedRace(row)
+
+		row.archived = now
+		row.version++
+
+		if err := r.record(race, r.storedRace(row), audit); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, row.id)
+	}
+
+	return ids, nil
+}
+
+func (r *memoryRacesRepo) List(in *racing.ListRacesRequest) ([]*racing.Race, string, error) {
+	terms, err := raceOrderTerms(in.GetOrderBy())
+	if err != nil {
+		return nil, "", err
+	}
+
+	size, err := pageSize(in.GetPageSize())
+	if err != nil {
+		return nil, "", err
+	}
+
+	now, err := statusInstant(r.clock, in.GetAsOf())
+	if err != nil {
+		return nil, "", err
+	}
+
+	match, err := r.filter(in.GetFilter(), now)
+	if err != nil {
+		return nil, "", err
+	}
+
+	columns, err := readColumns(in.GetReadMask(), terms)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Resume after the last race of the previous page, if there was one.
+	var after []interface{}
+	checksum := pageChecksum(in.GetFilter(), terms)
+	if in.GetPageToken() != "" {
+		keys, err := decodePageToken(in.GetPageToken(), checksum)
+		if err != nil {
+			return nil, "", err
+		}
+
+		after, err = decodeKeys(terms, keys)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var rows []*memoryRace
+	for _, row := range r.races {
+		if match(row) && (after == nil || row.sortsAfter(terms, after)) {
+			rows = append(rows, row)
+		}
+	}
+	sortRaces(rows, terms)
+
+	var (
+		races         []*racing.Race
+		nextPageToken string
+	)
+	for _, row := range rows {
+		if len(races) == size {
+			nextPageToken, err = page.EncodeToken(checksum, orderKeys(terms, races[size-1]))
+			if err != nil {
+				return nil, "", err
+			}
+			break
+		}
+
+		races = append(races, r.race(row, now, columns))
+	}
+
+	return races, nextPageToken, nil
+}
+
+func (r *memoryRacesRepo) Search(in *racing.SearchRacesRequest) ([]*racing.RaceSearchResult, string, error) {
+	words := search.Words(in.GetQuery())
+	if len(words) == 0 {
+		return nil, "", invalidField("query", "must contain a word to search for")
+	}
+
+	size, err := pageSize(in.GetPageSize())
+	if err != nil {
+		return nil, "", err
+	}
+
+	now, err := statusInstant(r.clock, in.GetAsOf())
+	if err != nil {
+		return nil, "", err
+	}
+
+	match, err := r.filter(in.GetFilter(), now)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Results are always ordered by score then ID, so a page token records
+	// the score and ID of the last result returned.
+	var (
+		afterScore float64
+		afterId    int64
+		paged      bool
+	)
+	checksum := pageChecksum(&racing.SearchRacesRequest{Query: in.GetQuery(), Filter: in.GetFilter()}, nil)
+	if in.GetPageToken() != "" {
+		keys, err := decodePageToken(in.GetPageToken(), checksum)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if err := page.DecodeKeys(keys, &afterScore, &afterId); err != nil {
+			return nil, "", invalidPageToken(err)
+		}
+		paged = true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Every race is indexed, whether or not it matches the filter.
+	names := make(map[int64]string, len(r.races))
+	for id, row := range r.races {
+		names[id] = row.name
+	}
+	hits := search.Match(names, words)
+
+	var ids []int64
+	for id, hit := range hits {
+		if !match(r.races[id]) {
+			continue
+		}
+		if paged && !(hit.Score > afterScore || (hit.Score == afterScore && id > afterId)) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := hits[ids[i]], hits[ids[j]]
+		if a.Score != b.Score {
+			return a.Score < b.Score
+		}
+		return ids[i] < ids[j]
+	})
+
+	var (
+		results       []*racing.RaceSearchResult
+		nextPageToken string
+	)
+	for _, id := range ids {
+		if len(results) == size {
+			last := ids[size-1]
+			nextPageToken, err = page.EncodeToken(checksum, []interface{}{hits[last].Score, last})
+			if err != nil {
+				return nil, "", err
+			}
+			break
+		}
+
+		// Scores are negated for callers, so that higher is more relevant.
+		hit := hits[id]
+		results = append(results, &racing.RaceSearchResult{
+			Race:    r.race(r.races[id], now, raceColumns),
+			Snippet: hit.Snippet,
+			Score:   -hit.Score,
+		})
+	}
+
+	return results, nextPageToken, nil
+}
+
+// filter resolves a filter, as applyFilter does, to a function reporting
+// whether a race matches it. Race status is derived relative to now.
+func (r *memoryRacesRepo) filter(filter *racing.ListRacesRequestFilter, now time.Time) (func(*memoryRace) bool, error) {
+	var matches []func(*memoryRace) bool
+
+	match := func(row *memoryRace) bool {
+		for _, m := range matches {
+			if !m(row) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if !filter.GetIncludeArchived() {
+		matches = append(matches, func(row *memoryRace) bool { return row.archived.IsZero() })
+	}
+
+	if filter == nil {
+		return match, nil
+	}
+
+	if len(filter.Ids) > 0 {
+		ids := int64Set(filter.Ids)
+		matches = append(matches, func(row *memoryRace) bool { return ids[row.id] })
+	}
+
+	if len(filter.MeetingIds) > 0 {
+		meetingIds := int64Set(filter.MeetingIds)
+		matches = append(matches, func(row *memoryRace) bool { return meetingIds[row.meetingId] })
+	}
+
+	if filter.VisibleOnly {
+		matches = append(matches, func(row *memoryRace) bool { return row.visible })
+	}
+
+	var from, to time.Time
+	if filter.AdvertisedStartFrom != nil {
+		t, err := ptypes.Timestamp(filter.AdvertisedStartFrom)
+		if err != nil {
+			return nil, invalidField("filter.advertised_start_from", "%s", err)
+		}
+		from = t
+
+		matches = append(matches, func(row *memoryRace) bool {
+			return instantMillis(row.advertisedStart) >= instantMillis(from)
+		})
+	}
+
+	if filter.AdvertisedStartTo != nil {
+		t, err := ptypes.Timestamp(filter.AdvertisedStartTo)
+		if err != nil {
+			return nil, invalidField("filter.advertised_start_to", "%s", err)
+		}
+		to = t
+
+		matches = append(matches, func(row *memoryRace) bool {
+			return instantMillis(row.advertisedStart) < instantMillis(to)
+		})
+	}
+
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return nil, invalidField("filter.advertised_start_from", "must be before advertised_start_to")
+	}
+
+	// The local date is matched in the time zone of each race's meeting, as
+	// localDateClause matches it.
+	if filter.LocalDate != "" {
+		date, err := time.Parse(meetingDateLayout, filter.LocalDate)
+		if err != nil {
+			return nil, invalidField("filter.local_date", "must be formatted YYYY-MM-DD")
+		}
+
+		matches = append(matches, func(row *memoryRace) bool {
+			from, to, err := localDay(date, r.timeZones[row.meetingId])
+			if err != nil {
+				return false
+			}
+			start := instantMillis(row.advertisedStart)
+			return start >= instantMillis(from) && start < instantMillis(to)
+		})
+	}
+
+	// Statuses are matched against the status derived as raceStatusExpr
+	// derives it.
+	if len(filter.Statuses) > 0 {
+		statuses := make(map[racing.RaceStatus]bool)
+		for _, status := range filter.Statuses {
+			if !validRaceStatus(status) {
+				return nil, invalidField("filter.statuses", "unknown status %q", status)
+			}
+			statuses[status] = true
+		}
+
+		matches = append(matches, func(row *memoryRace) bool {
+			status := row.status
+			if status == racing.RaceStatus_OPEN && instantMillis(row.advertisedStart) < instantMillis(now) {
+				status = racing.RaceStatus_CLOSED
+			}
+			return statuses[status]
+		})
+	}
+
+	// LIKE matches ignoring the case of ASCII letters only.
+	if filter.NameContains != "" {
+		contains := foldASCII(filter.NameContains)
+		matches = append(matches, func(row *memoryRace) bool {
+			return strings.Contains(foldASCII(row.name), contains)
+		})
+	}
+
+	if len(filter.Numbers) > 0 {
+		numbers := int64Set(filter.Numbers)
+		matches = append(matches, func(row *memoryRace) bool { return numbers[row.number] })
+	}
+
+	if filter.ResultedOnly {
+		matches = append(matches, func(row *memoryRace) bool { return r.resulted[row.id] })
+	}
+
+	if len(filter.Categories) > 0 {
+		categories := make(map[racing.RaceCategory]bool)
+		for _, category := range filter.Categories {
+			if !validRaceCategory(category) {
+				return nil, invalidField("filter.categories", "unknown category %q", category)
+			}
+			categories[category] = true
+		}
+
+		matches = append(matches, func(row *memoryRace) bool { return categories[row.category] })
+	}
+
+	return match, nil
+}
+
+// validateNew checks every field of a race being created, returning the
+// values to store for each. Races without a category take their meeting's.
+func (r *memoryRacesRepo) validateNew(race *racing.Race) (map[string]interface{}, error) {
+	paths := []string{"meeting_id", "name", "number", "visible", "advertised_start_time"}
+	if race.GetCategory() != racing.RaceCategory_CATEGORY_UNSPECIFIED {
+		paths = append(paths, "category")
+	}
+
+	values, err := r.validate(race, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := values["category"]; !ok {
+		values["category"] = r.meetings[race.MeetingId]
+	}
+
+	return values, nil
+}
+
+// validate checks the fields of a race named by paths, as racesRepo does,
+// returning the values to store for each, keyed by path.
+func (r *memoryRacesRepo) validate(race *racing.Race, paths []string) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+
+	for _, path := range paths {
+		switch path {
+		case "meeting_id":
+			if _, ok := r.meetings[race.MeetingId]; !ok {
+				return nil, invalidField("meeting_id", "meeting %d does not exist", race.MeetingId)
+			}
+			values[path] = race.MeetingId
+		case "name":
+			name := strings.TrimSpace(race.Name)
+			if name == "" {
+				return nil, invalidField("name", "is required")
+			}
+			values[path] = name
+		case "number":
+			if race.Number < 1 {
+				return nil, invalidField("number", "must be positive")
+			}
+			values[path] = race.Number
+		case "visible":
+			values[path] = race.Visible
+		case "advertised_start_time":
+			if race.AdvertisedStartTime == nil {
+				return nil, invalidField("advertised_start_time", "is required")
+			}
+			t, err := ptypes.Timestamp(race.AdvertisedStartTime)
+			if err != nil {
+				return nil, invalidField("advertised_start_time", "%s", err)
+			}
+			values[path] = t.UTC()
+		case "category":
+			if !validRaceCategory(race.Category) {
+				return nil, invalidField("category", "unknown category %q", race.Category)
+			}
+			values[path] = race.Category
+		}
+	}
+
+	return values, nil
+}
+
+// get returns the stored race with an ID, or ErrNotFound if there is none.
+func (r *memoryRacesRepo) get(raceId int64) (*memoryRace, error) {
+	row, ok := r.races[raceId]
+	if !ok {
+		return nil, fmt.Errorf("%w: race %d", ErrNotFound, raceId)
+	}
+
+	return row, nil
+}
+
+// race converts a stored race to a race as read from the given columns, as
+// scanRace does. Fields not read from any of the columns are left unset.
+func (m *memoryRace) race(now time.Time, columns []string) *racing.Race {
+	var race racing.Race
+
+	for _, column := range columns {
+		switch column {
+		case "id":
+			race.Id = m.id
+		case "meeting_id":
+			race.MeetingId = m.meetingId
+		case "name":
+			race.Name = m.name
+		case "number":
+			race.Number = m.number
+		case "visible":
+			race.Visible = m.visible
+		case "advertised_start_time":
+			race.AdvertisedStartTime, _ = ptypes.TimestampProto(m.advertisedStart)
+		case "status":
+			race.Status = effectiveStatus(m.status, m.advertisedStart, now)
+		case "version":
+			race.Etag = strconv.FormatInt(m.version, 10)
+		case "category":
+			race.Category = m.category
+		case "archived_time":
+			if !m.archived.IsZero() {
+				race.ArchivedTime, _ = ptypes.TimestampProto(m.archived)
+			}
+		}
+	}
+
+	return &race
+}
+
+// race converts a stored race as its race method does, adding its local start
+// time, in its meeting's time zone, when read from both columns it is read
+// from as scanRace does.
+func (r *memoryRacesRepo) race(row *memoryRace, now time.Time, columns []string) *racing.Race {
+	race := row.race(now, columns)
+
+	var started, zoned bool
+	for _, column := range columns {
+		started = started || column == "advertised_start_time"
+		zoned = zoned || column == "time_zone"
+	}
+	if started && zoned {
+		race.LocalStartTime, _ = localStartTime(row.advertisedStart, r.timeZones[row.meetingId])
+	}
+
+	return race
+}
+
+// storedRace converts a race held in memory as it is stored, with its
+// persisted status, as its history records it.
+func (r *memoryRacesRepo) storedRace(row *memoryRace) *racing.Race {
+	return r.race(row, time.Time{}, raceColumns)
+}
+
+// set stores validated values, keyed by path.
+func (m *memoryRace) set(values map[string]interface{}) {
+	for path, value := range values {
+		switch path {
+		case "meeting_id":
+			m.meetingId = value.(int64)
+		case "name":
+			m.name = value.(string)
+		case "number":
+			m.number = value.(int64)
+		case "visible":
+			m.visible = value.(bool)
+		case "advertised_start_time":
+			m.advertisedStart = value.(time.Time)
+		case "category":
+			m.category = value.(racing.RaceCategory)
+		}
+	}
+}
+
+// differs reports whether storing validated values would change the race,
+// comparing start times as instants as the upsert query does.
+func (m *memoryRace) differs(values map[string]interface{}) bool {
+	changed := *m
+	changed.set(values)
+
+	return changed.meetingId != m.meetingId ||
+		changed.name != m.name ||
+		changed.number != m.number ||
+		changed.visible != m.visible ||
+		instantMillis(changed.advertisedStart) != instantMillis(m.advertisedStart) ||
+		changed.category != m.category
+}
+
+// checkEtag checks, as writeRace does, that etag, if given, names the
+// current version of the race.
+func (m *memoryRace) checkEtag(etag string) error {
+	if etag == "" {
+		return nil
+	}
+
+	version, err := strconv.ParseInt(etag, 10, 64)
+	if err != nil || version != m.version {
+		return fmt.Errorf("%w: etag %q is not current for race %d", ErrAborted, etag, m.id)
+	}
+
+	return nil
+}
+
+// orderKey returns the value of a race that the field is ordered on, being
+// an int64 or a string.
+func (m *memoryRace) orderKey(field string) interface{} {
+	switch field {
+	case "advertised_start_time":
+		return instantMillis(m.advertisedStart)
+	case "meeting_id":
+		return m.meetingId
+	case "number":
+		return m.number
+	case "name":
+		return m.name
+	default:
+		return m.id
+	}
+}
+
+// sortsAfter reports whether a race sorts strictly after the position
+// recorded by the decoded page token values, as keysetClause matches.
+func (m *memoryRace) sortsAfter(terms []orderTerm, values []interface{}) bool {
+	for i, term := range terms {
+		value := values[i]
+		if term.field == "advertised_start_time" {
+			t, _ := time.Parse(time.RFC3339Nano, value.(string))
+			value = instantMillis(t)
+		}
+
+		if c := compareKeys(m.orderKey(term.field), value); c != 0 {
+			return (c > 0) != term.desc
+		}
+	}
+
+	return false
+}
+
+// sortRaces sorts races by terms, as orderClause does.
+func sortRaces(rows []*memoryRace, terms []orderTerm) {
+	sort.Slice(rows, func(i, j int) bool {
+		for _, term := range terms {
+			if c := compareKeys(rows[i].orderKey(term.field), rows[j].orderKey(term.field)); c != 0 {
+				return (c < 0) != term.desc
+			}
+		}
+		return false
+	})
+}
+
+// compareKeys compares two order keys of the same type, returning -1, 0 or
+// 1. Strings compare by their bytes, as SQLite's default collation does.
+func compareKeys(a, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		b := b.(int64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	case string:
+		return strings.Compare(a, b.(string))
+	}
+
+	return 0
+}
+
+// instantMillis returns the millisecond an instant falls in, the precision
+// SQLite compares times at.
+func instantMillis(t time.Time) int64 {
+	return t.Unix()*1000 + int64(t.Nanosecond())/int64(time.Millisecond)
+}
+
+// foldASCII folds the ASCII letters of s to lower case.
+func foldASCII(s string) string {
+	return strings.Map(func(r rune) rune {
+		if 'A' <= r && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return r
+	}, s)
+}
+
+func int64Set(values []int64) map[int64]bool {
+	set := make(map[int64]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+
+	return set
+}
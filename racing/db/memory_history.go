@@ -0,0 +1,43 @@
+package db
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+func (r *memoryRacesRepo) History(in *racing.ListRaceHistoryRequest) ([]*racing.RaceChange, string, error) {
+	size, checksum, after, err := historyPage(in)
+	if err != nil {
+		return nil, "", err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var changes []*racing.RaceChange
+	for _, change := range r.history {
+		if change.RaceId == in.RaceId && change.Id > after {
+			changes = append(changes, proto.Clone(change).(*racing.RaceChange))
+		}
+		if len(changes) > size {
+			break
+		}
+	}
+
+	return historyNextPage(changes, size, checksum)
+}
+
+// record appends the change from before to after to the history held in
+// memory, assigning its ID.
+func (r *memoryRacesRepo) record(before, after *racing.Race, audit Audit) error {
+	change, err := newRaceChange(before, after, audit, r.clock.Now())
+	if err != nil {
+		return err
+	}
+
+	change.Id = int64(len(r.history)) + 1
+	r.history = append(r.history, change)
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package db_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"git.neds.sh/matty/entain/racing/db"
+)
+
+func TestMigrate(t *testing.T) {
+	racingDB := emptyDB(t)
+
+	applied, err := migrate(t, racingDB)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if len(applied) == 0 {
+		t.Fatal("Migrate of an empty database applied nothing")
+	}
+
+	// Every migration is applied, in order.
+	statuses, err := db.SchemaStatus(racingDB)
+	if err != nil {
+		t.Fatalf("SchemaStatus: %v", err)
+	}
+	if len(statuses) != len(applied) {
+		t.Fatalf("statuses = %+v, want the %d migrations applied", statuses, len(applied))
+	}
+	for i, status := range statuses {
+		if status.Version != applied[i].Version || status.Name != applied[i].Name || status.Applied.IsZero() || !status.Known {
+			t.Errorf("status %d = %+v, want %+v applied", i, status, applied[i])
+		}
+		if i > 0 && status.Version <= statuses[i-1].Version {
+			t.Errorf("migration %d applied after %d", status.Version, statuses[i-1].Version)
+		}
+	}
+
+	// Migrating again changes nothing.
+	again, err := migrate(t, racingDB)
+	if err != nil {
+		t.Fatalf("Migrate again: %v", err)
+	}
+	if len(again) != 0 {
+		t.Errorf("Migrate again applied %+v, want nothing", again)
+	}
+
+	after, err := db.SchemaStatus(racingDB)
+	if err != nil {
+		t.Fatalf("SchemaStatus: %v", err)
+	}
+	if !reflect.DeepEqual(after, statuses) {
+		t.Errorf("statuses = %+v after migrating again, want %+v", after, statuses)
+	}
+}
+
+func TestMigrateSchemaTooNew(t *testing.T) {
+	racingDB, err := migratedDB(t)
+	if err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+
+	if _, err := racingDB.Exec(`INSERT INTO schema_version (version, name, applied_time) VALUES (1000, 'from_a_newer_build', '2021-03-02T12:00:00Z')`); err != nil {
+		t.Fatalf("recording migration: %v", err)
+	}
+
+	if _, err := db.Migrate(racingDB); !errors.Is(err, db.ErrSchemaTooNew) {
+		t.Errorf("Migrate: err = %v, want ErrSchemaTooNew", err)
+	}
+
+	// The unknown migration is reported last.
+	statuses, err := db.SchemaStatus(racingDB)
+	if err != nil {
+		t.Fatalf("SchemaStatus: %v", err)
+	}
+	if last := statuses[len(statuses)-1]; last.Version != 1000 || last.Known {
+		t.Errorf("last status = %+v, want unknown migration 1000", last)
+	}
+}
+
+func TestMigrateUnversionedDatabase(t *testing.T) {
+	racingDB := emptyDB(t)
+
+	// A database created before races had a status, version or category,
+	// or meetings a time zone, and before migrations were recorded.
+	for _, stmt := range []string{
+		`CREATE TABLE races (id INTEGER PRIMARY KEY, meeting_id INTEGER, name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME)`,
+		`CREATE TABLE meetings (id INTEGER PRIMARY KEY, name TEXT, country TEXT, date TEXT, category TEXT, status TEXT NOT NULL DEFAULT 'SCHEDULED')`,
+		`INSERT INTO races (id, meeting_id, name, number, visible, advertised_start_time) VALUES (1, 1, 'Flemington Cup', 1, 1, '2021-03-02T13:00:00Z'), (2, 5, 'Wentworth Dash', 1, 1, '2021-03-02T13:00:00Z'), (3, 11, 'Unseeded Stakes', 1, 1, '2021-03-02T13:00:00Z')`,
+		`INSERT INTO meetings (id, name, country, date, category) VALUES (1, 'Flemington', 'AU', '2021-03-02', 'THOROUGHBRED'), (5, 'Wentworth Park', 'AU', '2021-03-02', 'GREYHOUND'), (11, 'Unseeded', 'AU', '2021-03-02', 'THOROUGHBRED')`,
+	} {
+		if _, err := racingDB.Exec(stmt); err != nil {
+			t.Fatalf("creating unversioned database: %v", err)
+		}
+	}
+
+	if _, err := migrate(t, racingDB); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	// Races are left uncategorised, and the meetings seeded at the time
+	// take their venue's time zone.
+	for _, tc := range []struct {
+		query string
+		id    int64
+		want  string
+	}{
+		{`SELECT category FROM races WHERE id = ?`, 1, ""},
+		{`SELECT category FROM races WHERE id = ?`, 2, ""},
+		{`SELECT status FROM races WHERE id = ?`, 1, "OPEN"},
+		{`SELECT time_zone FROM meetings WHERE id = ?`, 1, "Australia/Melbourne"},
+		{`SELECT time_zone FROM meetings WHERE id = ?`, 5, "Australia/Sydney"},
+		{`SELECT time_zone FROM meetings WHERE id = ?`, 11, ""},
+	} {
+		var got string
+		if err := racingDB.QueryRow(tc.query, tc.id).Scan(&got); err != nil {
+			t.Fatalf("%s of %d: %v", tc.query, tc.id, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s of %d = %q, want %q", tc.query, tc.id, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"database/sql"
+
+	"git.neds.sh/matty/entain/shared/migrate"
+	"git.neds.sh/matty/entain/shared/search"
+)
+
+// migrations are the changes to the schema of the racing database, in the
+// order they are applied. A released migration is never changed; the schema
+// is changed by appending a new one.
+var migrations = []migrate.Migration{
+	{Version: 1, Name: "baseline", Up: migrateBaseline},
+	{Version: 2, Name: "races_start_time_and_meeting_indexes", Up: migrate.ExecAll(
+		// Races are filtered and ordered on their start time as an instant,
+		// so the index is on the same expression the queries use.
+		`CREATE INDEX IF NOT EXISTS races_advertised_start_time ON races (julianday(advertised_start_time))`,
+		`CREATE INDEX IF NOT EXISTS races_meeting_id ON races (meeting_id)`,
+	)},
+	{Version: 3, Name: "race_history", Up: migrate.ExecAll(
+		// Each change to a race is recorded with the fields it set, as a
+		// JSON array, and is never altered or removed, even along with the
+		// race.
+		`CREATE TABLE race_history (id INTEGER PRIMARY KEY, race_id INTEGER NOT NULL, action TEXT NOT NULL, actor TEXT NOT NULL, reason TEXT NOT NULL, changed_time DATETIME NOT NULL, version INTEGER NOT NULL, changes TEXT NOT NULL)`,
+		`CREATE INDEX race_history_race_id ON race_history (race_id, id)`,
+		`CREATE TRIGGER race_history_no_update BEFORE UPDATE ON race_history BEGIN SELECT RAISE(ABORT, 'race history is append-only'); END`,
+		`CREATE TRIGGER race_history_no_delete BEFORE DELETE ON race_history BEGIN SELECT RAISE(ABORT, 'race history is append-only'); END`,
+	)},
+	{Version: 4, Name: "races_archived_time", Up: migrate.ExecAll(
+		// Races long finished are archived by retention rather than deleted.
+		// Archived races are only listed when asked for, so the start times
+		// of those not archived are indexed on their own.
+		`ALTER TABLE races ADD COLUMN archived_time DATETIME`,
+		`CREATE INDEX races_live_advertised_start_time ON races (julianday(advertised_start_time)) WHERE archived_time IS NULL`,
+	)},
+	{Version: 5, Name: "meetings_time_zone", Up: migrate.ExecAll(
+		// Meetings already seeded take the time zone of the venue they were
+		// seeded at, and any other meeting is left without one, so that
+		// races can be given and found by their local start times. The
+		// venues are those seeded when the migration was written, not
+		// whatever is seeded now.
+		`ALTER TABLE meetings ADD COLUMN time_zone TEXT NOT NULL DEFAULT ''`,
+		`UPDATE meetings SET time_zone = 'Australia/Melbourne' WHERE name = 'Flemington'`,
+		`UPDATE meetings SET time_zone = 'Australia/Sydney' WHERE name IN ('Randwick', 'Menangle', 'Wentworth Park')`,
+		`UPDATE meetings SET time_zone = 'Australia/Brisbane' WHERE name = 'Eagle Farm'`,
+		`UPDATE meetings SET time_zone = 'Pacific/Auckland' WHERE name IN ('Ellerslie', 'Addington')`,
+		`UPDATE meetings SET time_zone = 'Asia/Hong_Kong' WHERE name = 'Sha Tin'`,
+		`UPDATE meetings SET time_zone = 'Europe/London' WHERE name IN ('Cheltenham', 'Romford')`,
+	)},
+}
+
+// migrateBaseline creates the schema as it was before migrations were
+// introduced. Databases created since races were first stored may already
+// have some or all of it, created as each repository was initialised, so
+// every change is made only if it is missing.
+func migrateBaseline(tx *sql.Tx) error {
+	err := migrate.ExecAll(
+		`CREATE TABLE IF NOT EXISTS races (id INTEGER PRIMARY KEY, meeting_id INTEGER, name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME, status TEXT NOT NULL DEFAULT 'OPEN', version INTEGER NOT NULL DEFAULT 1, category TEXT NOT NULL DEFAULT '')`,
+	)(tx)
+
+	// Databases created before races had a persisted status lack the column.
+	if err == nil {
+		err = migrate.AddColumnIfMissing(tx, "races", "status", `TEXT NOT NULL DEFAULT 'OPEN'`)
+	}
+
+	// Likewise for those created before races were versioned.
+	if err == nil {
+		err = migrate.AddColumnIfMissing(tx, "races", "version", `INTEGER NOT NULL DEFAULT 1`)
+	}
+
+	// And those created before races were categorised, whose races are
+	// left uncategorised, as categories are data for the seed to give.
+	if err == nil {
+		err = migrate.AddColumnIfMissing(tx, "races", "category", `TEXT NOT NULL DEFAULT ''`)
+	}
+
+	// Races are searched on name.
+	if err == nil {
+		err = search.CreateIndex(tx, "races")
+	}
+	if err != nil {
+		return err
+	}
+
+	return migrate.ExecAll(
+		`CREATE INDEX IF NOT EXISTS races_category ON races (category)`,
+		`CREATE TABLE IF NOT EXISTS meetings (id INTEGER PRIMARY KEY, name TEXT, country TEXT, date TEXT, category TEXT, status TEXT NOT NULL DEFAULT 'SCHEDULED')`,
+		`CREATE TABLE IF NOT EXISTS runners (id INTEGER PRIMARY KEY, race_id INTEGER, number INTEGER, name TEXT, barrier INTEGER, weight REAL, scratched INTEGER NOT NULL DEFAULT 0, scratched_time DATETIME, scratching_reason TEXT NOT NULL DEFAULT '', UNIQUE (race_id, number))`,
+		`CREATE TABLE IF NOT EXISTS results (race_id INTEGER PRIMARY KEY, final INTEGER NOT NULL, recorded_time DATETIME NOT NULL)`,
+		`CREATE TABLE IF NOT EXISTS result_placings (race_id INTEGER, runner_id INTEGER, position INTEGER, PRIMARY KEY (race_id, runner_id))`,
+		`CREATE TABLE IF NOT EXISTS result_dividends (race_id INTEGER, bet_type TEXT, runner_id INTEGER, amount_cents INTEGER, PRIMARY KEY (race_id, bet_type, runner_id))`,
+		`CREATE TABLE IF NOT EXISTS markets (race_id INTEGER PRIMARY KEY, version INTEGER NOT NULL)`,
+		`CREATE TABLE IF NOT EXISTS prices (runner_id INTEGER PRIMARY KEY, race_id INTEGER, win_odds REAL, place_odds REAL, version INTEGER, updated_time DATETIME)`,
+		`CREATE TABLE IF NOT EXISTS price_history (id INTEGER PRIMARY KEY, runner_id INTEGER, race_id INTEGER, win_odds REAL, place_odds REAL, version INTEGER, updated_time DATETIME)`,
+		`CREATE INDEX IF NOT EXISTS price_history_race_id ON price_history (race_id)`,
+	)(tx)
+}
@@ -0,0 +1,231 @@
+package db
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/shared/page"
+)
+
+// raceOrderField describes how a single race field is ordered and paged on.
+type raceOrderField struct {
+	// field is the race field ordered on.
+	field string
+	// expr is the SQL expression the field is ordered by.
+	expr string
+	// param is the placeholder a page token value is compared through, so
+	// that it compares exactly like expr.
+	param string
+	// key extracts the value of the field recorded in a page token.
+	key func(*racing.Race) interface{}
+	// decode parses a page token value back into a query argument.
+	decode func(json.RawMessage) (interface{}, error)
+}
+
+// raceOrderFields maps each orderable race field onto how it is sorted. Only
+// fields present here may be used to order races.
+var raceOrderFields = map[racing.RaceOrder_Field]raceOrderField{
+	racing.RaceOrder_ADVERTISED_START_TIME: {
+		// Start times are stored as RFC3339 text which may carry differing
+		// offsets, so they are compared as instants rather than strings.
+		field:  "advertised_start_time",
+		expr:   "julianday(advertised_start_time)",
+		param:  "julianday(?)",
+		key:    func(r *racing.Race) interface{} { return formatTime(r.AdvertisedStartTime.AsTime()) },
+		decode: decodeTimeKey,
+	},
+	racing.RaceOrder_MEETING_ID: {
+		field:  "meeting_id",
+		expr:   "meeting_id",
+		param:  "?",
+		key:    func(r *racing.Race) interface{} { return r.MeetingId },
+		decode: decodeIntKey,
+	},
+	racing.RaceOrder_NUMBER: {
+		field:  "number",
+		expr:   "number",
+		param:  "?",
+		key:    func(r *racing.Race) interface{} { return r.Number },
+		decode: decodeIntKey,
+	},
+	racing.RaceOrder_NAME: {
+		field:  "name",
+		expr:   "name",
+		param:  "?",
+		key:    func(r *racing.Race) interface{} { return r.Name },
+		decode: decodeStringKey,
+	},
+	racing.RaceOrder_ID: {
+		field:  "id",
+		expr:   "id",
+		param:  "?",
+		key:    func(r *racing.Race) interface{} { return r.Id },
+		decode: decodeIntKey,
+	},
+}
+
+// defaultRaceOrder is applied when a caller does not supply any ordering.
+var defaultRaceOrder = []*racing.RaceOrder{
+	{Field: racing.RaceOrder_ADVERTISED_START_TIME, Direction: racing.RaceOrder_DESC},
+}
+
+// orderTerm is a single resolved key of an ORDER BY clause.
+type orderTerm struct {
+	raceOrderField
+	desc bool
+}
+
+func (t orderTerm) direction() string {
+	if t.desc {
+		return "DESC"
+	}
+
+	return "ASC"
+}
+
+// comparator returns the operator matching rows sorting after a key value.
+func (t orderTerm) comparator() string {
+	if t.desc {
+		return "<"
+	}
+
+	return ">"
+}
+
+// raceOrderTerms validates the requested ordering against the allow-list and
+// resolves it to columns, appending id as a final tie-breaker if absent.
+func raceOrderTerms(orderBy []*racing.RaceOrder) ([]orderTerm, error) {
+	if len(orderBy) == 0 {
+		orderBy = defaultRaceOrder
+	}
+
+	var (
+		terms []orderTerm
+		seen  = make(map[racing.RaceOrder_Field]bool)
+	)
+
+	for _, order := range orderBy {
+		field, ok := raceOrderFields[order.GetField()]
+		if !ok {
+			return nil, invalidField("order_by", "cannot order races by field %q", order.GetField())
+		}
+
+		if seen[order.GetField()] {
+			return nil, invalidField("order_by", "races ordered by field %q more than once", order.GetField())
+		}
+		seen[order.GetField()] = true
+
+		var desc bool
+		switch order.GetDirection() {
+		case racing.RaceOrder_DIRECTION_UNSPECIFIED, racing.RaceOrder_ASC:
+		case racing.RaceOrder_DESC:
+			desc = true
+		default:
+			return nil, invalidField("order_by", "unknown order direction %q", order.GetDirection())
+		}
+
+		terms = append(terms, orderTerm{raceOrderField: field, desc: desc})
+	}
+
+	if !seen[racing.RaceOrder_ID] {
+		terms = append(terms, orderTerm{raceOrderField: raceOrderFields[racing.RaceOrder_ID]})
+	}
+
+	return terms, nil
+}
+
+// orderClause renders terms as an ORDER BY clause.
+func orderClause(terms []orderTerm) string {
+	var keys []string
+	for _, term := range terms {
+		keys = append(keys, term.expr+" "+term.direction())
+	}
+
+	return " ORDER BY " + strings.Join(keys, ", ")
+}
+
+// orderKeys extracts the values of each ordering term from a race, for use
+// as the position recorded in a page token.
+func orderKeys(terms []orderTerm, race *racing.Race) []interface{} {
+	var keys []interface{}
+	for _, term := range terms {
+		keys = append(keys, term.key(race))
+	}
+
+	return keys
+}
+
+// keysetClause builds a WHERE clause matching only rows that sort strictly
+// after the position recorded by keys. For terms (a, b, id) this expands to
+// a > ? OR (a = ? AND b > ?) OR (a = ? AND b = ? AND id > ?), with operators
+// flipped for descending terms.
+func keysetClause(terms []orderTerm, keys []json.RawMessage) (string, []interface{}, error) {
+	values, err := decodeKeys(terms, keys)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var (
+		branches []string
+		args     []interface{}
+	)
+
+	for i, term := range terms {
+		var conds []string
+		for j := 0; j < i; j++ {
+			conds = append(conds, terms[j].expr+" = "+terms[j].param)
+			args = append(args, values[j])
+		}
+		conds = append(conds, term.expr+" "+term.comparator()+" "+term.param)
+		args = append(args, values[i])
+
+		branches = append(branches, "("+strings.Join(conds, " AND ")+")")
+	}
+
+	return "(" + strings.Join(branches, " OR ") + ")", args, nil
+}
+
+// decodeKeys decodes the values of each ordering term recorded by keys.
+func decodeKeys(terms []orderTerm, keys []json.RawMessage) ([]interface{}, error) {
+	if len(keys) != len(terms) {
+		return nil, invalidPageToken(page.ErrOrderMismatch)
+	}
+
+	values := make([]interface{}, len(keys))
+	for i, term := range terms {
+		value, err := term.decode(keys[i])
+		if err != nil {
+			return nil, invalidPageToken(page.ErrMalformedToken)
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+func decodeIntKey(raw json.RawMessage) (interface{}, error) {
+	var v int64
+	err := json.Unmarshal(raw, &v)
+	return v, err
+}
+
+func decodeStringKey(raw json.RawMessage) (interface{}, error) {
+	var v string
+	err := json.Unmarshal(raw, &v)
+	return v, err
+}
+
+func decodeTimeKey(raw json.RawMessage) (interface{}, error) {
+	var v string
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+
+	if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
@@ -0,0 +1,277 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// PricesRepo provides repository access to the fixed odds markets of races.
+type PricesRepo interface {
+	// Init will initialise our prices repository.
+	Init() error
+
+	// Update will apply a batch of prices to a race's market as a new
+	// version, recording each price in the market's history, and the change
+	// in the race's history, attributed to audit.
+	Update(in *racing.UpdatePricesRequest, at time.Time, audit Audit) (*racing.Market, error)
+
+	// Get will return the current market of a race, along with its price
+	// history if requested, or ErrNotFound if the race has not been priced.
+	Get(raceId int64, includeHistory bool) (*racing.Market, []*racing.Price, error)
+}
+
+type pricesRepo struct {
+	db *sql.DB
+}
+
+// NewPricesRepo creates a new prices repository.
+func NewPricesRepo(db *sql.DB) PricesRepo {
+	return &pricesRepo{db: db}
+}
+
+// Init does nothing, as no prices are seeded. The prices tables are
+// created by migrations.
+func (r *pricesRepo) Init() error {
+	return nil
+}
+
+func (r *pricesRepo) Get(raceId int64, includeHistory bool) (*racing.Market, []*racing.Price, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	market, err := r.getMarket(tx, raceId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !includeHistory {
+		return market, nil, nil
+	}
+
+	rows, err := tx.Query(getPriceQueries()[pricesHistory], raceId)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	history, err := r.scanPrices(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return market, history, nil
+}
+
+func (r *pricesRepo) Update(in *racing.UpdatePricesRequest, at time.Time, audit Audit) (*racing.Market, error) {
+	if in.Version < 1 {
+		return nil, invalidField("version", "must be positive")
+	}
+
+	if len(in.Prices) == 0 {
+		return nil, invalidField("prices", "no prices given")
+	}
+
+	priced := make(map[int64]bool)
+	for _, price := range in.Prices {
+		if price.WinOdds <= 1 || price.PlaceOdds <= 1 {
+			return nil, invalidField("prices", "odds for runner %d must be greater than 1", price.RunnerId)
+		}
+
+		if priced[price.RunnerId] {
+			return nil, invalidField("prices", "runner %d is priced more than once", price.RunnerId)
+		}
+		priced[price.RunnerId] = true
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Markets are only traded while their race is open or suspended.
+	var (
+		persisted       string
+		advertisedStart time.Time
+	)
+	err = tx.QueryRow(`SELECT status, advertised_start_time FROM races WHERE id = ?`, in.RaceId).Scan(&persisted, &advertisedStart)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: race %d", ErrNotFound, in.RaceId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch status := effectiveStatus(racing.RaceStatus(racing.RaceStatus_value[persisted]), advertisedStart, at); status {
+	case racing.RaceStatus_OPEN, racing.RaceStatus_SUSPENDED:
+	default:
+		return nil, fmt.Errorf("%w: race %d is %s", ErrFailedPrecondition, in.RaceId, status)
+	}
+
+	var version int64
+	err = tx.QueryRow(getPriceQueries()[pricesGetVersion], in.RaceId).Scan(&version)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	if in.Version <= version {
+		return nil, fmt.Errorf("%w: market for race %d is already at version %d", ErrFailedPrecondition, in.RaceId, version)
+	}
+
+	scratched, err := raceRunners(tx, in.RaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := &racing.Market{}
+	if version > 0 {
+		if previous, err = r.getMarket(tx, in.RaceId); err != nil {
+			return nil, err
+		}
+	}
+
+	queries := getPriceQueries()
+	updated := formatTime(at)
+
+	for _, price := range in.Prices {
+		s, ok := scratched[price.RunnerId]
+		if !ok {
+			return nil, invalidField("prices", "runner %d is not in race %d", price.RunnerId, in.RaceId)
+		}
+		if s {
+			return nil, invalidField("prices", "runner %d was scratched", price.RunnerId)
+		}
+
+		args := []interface{}{price.RunnerId, in.RaceId, price.WinOdds, price.PlaceOdds, in.Version, updated}
+
+		if _, err := tx.Exec(queries[pricesUpsert], args...); err != nil {
+			return nil, err
+		}
+
+		if _, err := tx.Exec(queries[pricesAddHistory], args...); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.Exec(queries[pricesUpsertMarket], in.RaceId, in.Version); err != nil {
+		return nil, err
+	}
+
+	market, err := r.getMarket(tx, in.RaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordRaceFields(tx, in.RaceId, marketChanges(previous, market), audit, at); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return market, nil
+}
+
+// marketChanges describes the change of a race's market from before to
+// after, as the fields of the race's history it sets: the market's version,
+// and the odds of each runner whose odds changed, ordered by runner ID. A
+// market not yet priced has no version or odds.
+func marketChanges(before, after *racing.Market) []*racing.FieldChange {
+	var oldVersion string
+	if before.Version > 0 {
+		oldVersion = strconv.FormatInt(before.Version, 10)
+	}
+
+	changes := []*racing.FieldChange{
+		{Field: "market.version", OldValue: oldVersion, NewValue: strconv.FormatInt(after.Version, 10)},
+	}
+
+	old := make(map[int64]*racing.Price)
+	for _, price := range before.Prices {
+		old[price.RunnerId] = price
+	}
+
+	// Prices are listed by runner ID.
+	for _, price := range after.Prices {
+		var oldWin, oldPlace string
+		if was, ok := old[price.RunnerId]; ok {
+			oldWin, oldPlace = formatOdds(was.WinOdds), formatOdds(was.PlaceOdds)
+		}
+
+		if win := formatOdds(price.WinOdds); win != oldWin {
+			changes = append(changes, &racing.FieldChange{Field: runnerField(price.RunnerId, "win_odds"), OldValue: oldWin, NewValue: win})
+		}
+		if place := formatOdds(price.PlaceOdds); place != oldPlace {
+			changes = append(changes, &racing.FieldChange{Field: runnerField(price.RunnerId, "place_odds"), OldValue: oldPlace, NewValue: place})
+		}
+	}
+
+	return changes
+}
+
+// formatOdds renders odds as they are written in JSON.
+func formatOdds(odds float64) string {
+	return strconv.FormatFloat(odds, 'f', -1, 64)
+}
+
+// getMarket fetches the current market of a race within a transaction.
+func (r *pricesRepo) getMarket(tx *sql.Tx, raceId int64) (*racing.Market, error) {
+	market := &racing.Market{RaceId: raceId}
+
+	err := tx.QueryRow(getPriceQueries()[pricesGetVersion], raceId).Scan(&market.Version)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: race %d has not been priced", ErrNotFound, raceId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(getPriceQueries()[pricesList], raceId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	market.Prices, err = r.scanPrices(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return market, nil
+}
+
+func (r *pricesRepo) scanPrices(rows *sql.Rows) ([]*racing.Price, error) {
+	var prices []*racing.Price
+
+	for rows.Next() {
+		var (
+			price   racing.Price
+			updated time.Time
+		)
+
+		if err := rows.Scan(&price.RunnerId, &price.WinOdds, &price.PlaceOdds, &price.Version, &updated); err != nil {
+			return nil, err
+		}
+
+		ts, err := ptypes.TimestampProto(updated)
+		if err != nil {
+			return nil, err
+		}
+
+		price.UpdatedTime = ts
+
+		prices = append(prices, &price)
+	}
+
+	return prices, rows.Err()
+}
@@ -1,9 +1,22 @@
 package db
 
 const (
-	racesList = "list"
+	racesList          = "list"
+	racesUpdateStatus  = "updateStatus"
+	racesInsert        = "insert"
+	racesNextToJump    = "nextToJump"
+	racesUpsert        = "upsert"
+	racesSearch        = "search"
+	racesSelect        = "select"
+	racesHistory       = "history"
+	racesHistoryInsert = "historyInsert"
+	racesArchive       = "archive"
 )
 
+// raceTimeZoneExpr selects the time zone of a race's meeting, which is read
+// as if it were a column of the race.
+const raceTimeZoneExpr = `(SELECT meetings.time_zone FROM meetings WHERE meetings.id = meeting_id) AS time_zone`
+
 func getRaceQueries() map[string]string {
 	return map[string]string{
 		racesList: `
@@ -13,8 +26,209 @@ func getRaceQueries() map[string]string {
 				name, 
 				number, 
 				visible, 
-				advertised_start_time 
+				advertised_start_time,
+				status,
+				version,
+				category,
+				archived_time,
+				` + raceTimeZoneExpr + `
 			FROM races
 		`,
+		racesSelect: `
+			SELECT %s FROM races
+		`,
+		racesUpdateStatus: `
+			UPDATE races SET status = ?, version = version + 1 WHERE id = ?
+		`,
+		racesArchive: `
+			UPDATE races SET archived_time = ?, version = version + 1 WHERE id = ?
+		`,
+		racesHistory: `
+			SELECT id, race_id, action, actor, reason, changed_time, version, changes
+			FROM race_history
+			WHERE race_id = ? AND id > ?
+			ORDER BY id
+			LIMIT ?
+		`,
+		racesHistoryInsert: `
+			INSERT INTO race_history (race_id, action, actor, reason, changed_time, version, changes) VALUES (?, ?, ?, ?, ?, ?, ?)
+		`,
+		racesNextToJump: `
+			SELECT
+				id,
+				meeting_id,
+				name,
+				number,
+				visible,
+				advertised_start_time,
+				status,
+				version,
+				category,
+				archived_time,
+				` + raceTimeZoneExpr + `
+			FROM (
+				SELECT
+					*,
+					ROW_NUMBER() OVER (PARTITION BY meeting_id ORDER BY julianday(advertised_start_time), id) AS meeting_rank
+				FROM races
+				WHERE status = 'OPEN' AND visible = 1 AND julianday(advertised_start_time) >= julianday(?)%s
+			)
+		`,
+		racesSearch: `
+			SELECT
+				id,
+				meeting_id,
+				name,
+				number,
+				visible,
+				advertised_start_time,
+				status,
+				version,
+				category,
+				archived_time,
+				` + raceTimeZoneExpr + `,
+				snippet,
+				score
+			FROM (
+				SELECT
+					rowid AS match_id,
+					snippet(races_fts, 0, '<mark>', '</mark>', '…', ?) AS snippet,
+					bm25(races_fts) AS score
+				FROM races_fts
+				WHERE races_fts MATCH ?
+			)
+			JOIN races ON races.id = match_id
+		`,
+		racesUpsert: `
+			INSERT INTO races(id, meeting_id, name, number, visible, advertised_start_time, status, category) VALUES (?,?,?,?,?,?,?,?)
+			ON CONFLICT (id) DO UPDATE SET
+				meeting_id = excluded.meeting_id,
+				name = excluded.name,
+				number = excluded.number,
+				visible = excluded.visible,
+				advertised_start_time = excluded.advertised_start_time,
+				category = excluded.category,
+				version = version + 1
+			WHERE
+				meeting_id IS NOT excluded.meeting_id OR
+				name IS NOT excluded.name OR
+				number IS NOT excluded.number OR
+				visible IS NOT excluded.visible OR
+				julianday(advertised_start_time) IS NOT julianday(excluded.advertised_start_time) OR
+				category IS NOT excluded.category
+		`,
+		racesInsert: `
+			INSERT INTO races(meeting_id, name, number, visible, advertised_start_time, status, category) VALUES (?,?,?,?,?,?,?)
+		`,
+	}
+}
+
+const (
+	meetingsList = "list"
+)
+
+func getMeetingQueries() map[string]string {
+	return map[string]string{
+		meetingsList: `
+			SELECT
+				id,
+				name,
+				country,
+				date,
+				category,
+				status,
+				time_zone
+			FROM meetings
+		`,
+	}
+}
+
+const (
+	runnersList    = "list"
+	runnersScratch = "scratch"
+)
+
+func getRunnerQueries() map[string]string {
+	return map[string]string{
+		runnersList: `
+			SELECT
+				id,
+				race_id,
+				number,
+				name,
+				barrier,
+				weight,
+				scratched,
+				scratched_time,
+				scratching_reason
+			FROM runners
+		`,
+		runnersScratch: `
+			UPDATE runners SET scratched = 1, scratched_time = ?, scratching_reason = ? WHERE id = ?
+		`,
+	}
+}
+
+const (
+	resultsGet            = "get"
+	resultsGetPlacings    = "getPlacings"
+	resultsGetDividends   = "getDividends"
+	resultsUpsert         = "upsert"
+	resultsInsertPlacing  = "insertPlacing"
+	resultsInsertDividend = "insertDividend"
+)
+
+func getResultQueries() map[string]string {
+	return map[string]string{
+		resultsGet: `
+			SELECT race_id, final, recorded_time FROM results WHERE race_id = ?
+		`,
+		resultsGetPlacings: `
+			SELECT runner_id, position FROM result_placings WHERE race_id = ?
+		`,
+		resultsGetDividends: `
+			SELECT bet_type, runner_id, amount_cents FROM result_dividends WHERE race_id = ?
+		`,
+		resultsUpsert: `
+			INSERT OR REPLACE INTO results(race_id, final, recorded_time) VALUES (?,?,?)
+		`,
+		resultsInsertPlacing: `
+			INSERT INTO result_placings(race_id, runner_id, position) VALUES (?,?,?)
+		`,
+		resultsInsertDividend: `
+			INSERT INTO result_dividends(race_id, bet_type, runner_id, amount_cents) VALUES (?,?,?,?)
+		`,
+	}
+}
+
+const (
+	pricesGetVersion   = "getVersion"
+	pricesList         = "list"
+	pricesHistory      = "history"
+	pricesUpsertMarket = "upsertMarket"
+	pricesUpsert       = "upsert"
+	pricesAddHistory   = "addHistory"
+)
+
+func getPriceQueries() map[string]string {
+	return map[string]string{
+		pricesGetVersion: `
+			SELECT version FROM markets WHERE race_id = ?
+		`,
+		pricesList: `
+			SELECT runner_id, win_odds, place_odds, version, updated_time FROM prices WHERE race_id = ? ORDER BY runner_id
+		`,
+		pricesHistory: `
+			SELECT runner_id, win_odds, place_odds, version, updated_time FROM price_history WHERE race_id = ? ORDER BY id
+		`,
+		pricesUpsertMarket: `
+			INSERT OR REPLACE INTO markets(race_id, version) VALUES (?,?)
+		`,
+		pricesUpsert: `
+			INSERT OR REPLACE INTO prices(runner_id, race_id, win_odds, place_odds, version, updated_time) VALUES (?,?,?,?,?,?)
+		`,
+		pricesAddHistory: `
+			INSERT INTO price_history(runner_id, race_id, win_odds, place_odds, version, updated_time) VALUES (?,?,?,?,?,?)
+		`,
 	}
 }
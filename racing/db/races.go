@@ -2,104 +2,1103 @@ package db
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	_ "github.com/mattn/go-sqlite3"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/protobuf/proto"
 
 	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/shared/page"
+	"git.neds.sh/matty/entain/shared/search"
 )
 
+// likeEscaper escapes the wildcards of a LIKE pattern, for use with ESCAPE '\'.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// formatTime renders a time as RFC3339 text, the format start times are
+// stored in.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
 // RacesRepo provides repository access to races.
 type RacesRepo interface {
 	// Init will initialise our races repository.
 	Init() error
 
-	// List will return a list of races.
-	List(filter *racing.ListRacesRequestFilter) ([]*racing.Race, error)
+	// List will return a page of races, along with a token for the next
+	// page, or an empty token if there are no further races. Only the
+	// columns needed for the fields named by the request's read mask, and to
+	// page on, are read.
+	List(in *racing.ListRacesRequest) ([]*racing.Race, string, error)
+
+	// GetRaceById will return a single race, or ErrNotFound if none match
+	// the ID, with its status derived as of asOf, if given. Only the columns
+	// needed for the fields named by readMask, if given, are read.
+	GetRaceById(raceId int64, asOf *timestamp.Timestamp, readMask *field_mask.FieldMask) (*racing.Race, error)
+
+	// BatchGet will return the races with the given IDs in the order given,
+	// along with the IDs for which no race exists. Unless missing races are
+	// allowed, any missing race fails the call with ErrNotFound.
+	BatchGet(in *racing.BatchGetRacesRequest) ([]*racing.Race, []int64, error)
+
+	// TransitionRace moves a race on to a new status, provided the move is
+	// legal from its current status and etag, if given, is current.
+	TransitionRace(raceId int64, status racing.RaceStatus, etag string, audit Audit) (*racing.Race, error)
+
+	// NextToJump will return the visible races still open, soonest to start
+	// first.
+	NextToJump(in *racing.ListNextToJumpRequest) ([]*racing.NextToJump, error)
+
+	// Create will validate and insert a new, open race, assigning its ID.
+	Create(race *racing.Race, audit Audit) (*racing.Race, error)
+
+	// Update will validate and apply the fields of a race named by paths,
+	// provided the race's etag, if given, is current.
+	Update(race *racing.Race, paths []string, audit Audit) (*racing.Race, error)
+
+	// Import will validate and upsert a batch of races on their IDs, in a
+	// single transaction. If any race is invalid, or dryRun is set, nothing
+	// is written.
+	Import(races []*racing.Race, dryRun bool, audit Audit) (*ImportResult, error)
+
+	// Delete will delete a race along with its runners and prices, unless
+	// the race has a recorded result or etag, if given, is not current.
+	Delete(raceId int64, etag string, audit Audit) error
+
+	// Search will return a page of the races whose names match a query, most
+	// relevant first, along with a token for the next page, or an empty
+	// token if there are no further races.
+	Search(in *racing.SearchRacesRequest) ([]*racing.RaceSearchResult, string, error)
+
+	// History will return a page of the changes made to a race, oldest
+	// first, along with a token for the next page, or an empty token if
+	// there are no further changes. Every write above records its changes,
+	// attributed to its audit, in the same transaction.
+	History(in *racing.ListRaceHistoryRequest) ([]*racing.RaceChange, string, error)
+
+	// Archive will archive up to limit races that have finished, as FINAL or
+	// ABANDONED, and were advertised to start more than olderThan ago,
+	// oldest first, returning the IDs of those it archived. Archived races
+	// are kept, but left out of List and Search unless asked for.
+	Archive(olderThan time.Duration, limit int, audit Audit) ([]int64, error)
+}
+
+// maxBatchGet is the most races that may be fetched in a single batch.
+const maxBatchGet = 500
+
+const (
+	// defaultNextToJump is the number of races next to jump returned when a
+	// caller does not ask for a number.
+	defaultNextToJump = 10
+	// maxNextToJump is the most races next to jump returned.
+	maxNextToJump = 100
+)
+
+// ImportResult reports the outcome of importing a batch of races.
+type ImportResult struct {
+	// Created, Updated and Unchanged count the races of the batch by how
+	// they were, or in a dry run would have been, written.
+	Created, Updated, Unchanged int64
+	// Errors holds why each invalid race could not be imported, keyed by
+	// its index in the batch.
+	Errors map[int]error
+	// Committed reports whether the batch was written.
+	Committed bool
+}
+
+// raceUpdateColumns maps the fields of a race that may be updated onto the
+// columns they are stored in.
+var raceUpdateColumns = map[string]string{
+	"meeting_id":            "meeting_id",
+	"name":                  "name",
+	"number":                "number",
+	"visible":               "visible",
+	"advertised_start_time": "advertised_start_time",
+	"category":              "category",
+}
+
+// raceReadColumns maps the fields of a race that may be read onto the
+// columns they are read from.
+var raceReadColumns = map[string][]string{
+	"id":                    {"id"},
+	"meeting_id":            {"meeting_id"},
+	"name":                  {"name"},
+	"number":                {"number"},
+	"visible":               {"visible"},
+	"advertised_start_time": {"advertised_start_time"},
+	"status":                {"status", "advertised_start_time"},
+	"etag":                  {"version"},
+	"category":              {"category"},
+	"archived_time":         {"archived_time"},
+	"local_start_time":      {"advertised_start_time", "time_zone"},
+}
+
+// raceColumns are all of the columns a race is read from, in the order they
+// are selected. The time zone is its meeting's, selected by raceTimeZoneExpr.
+var raceColumns = []string{"id", "meeting_id", "name", "number", "visible", "advertised_start_time", "status", "version", "category", "archived_time", "time_zone"}
+
+type racesRepo struct {
+	db    *sql.DB
+	clock Clock
+}
+
+// NewRacesRepo creates a new races repository, deriving race status from
+// clock.
+func NewRacesRepo(db *sql.DB, clock Clock) RacesRepo {
+	return &racesRepo{db: db, clock: clock}
+}
+
+// Init does nothing, as dummy races are only seeded by the seed command.
+// The races table is created by migrations.
+func (r *racesRepo) Init() error {
+	return nil
+}
+
+func (r *racesRepo) GetRaceById(raceId int64, asOf *timestamp.Timestamp, readMask *field_mask.FieldMask) (*racing.Race, error) {
+	var (
+		err   error
+		query string
+		args  []interface{}
+	)
+
+	now, err := r.now(asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := readColumns(readMask, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	query = selectRaces(columns)
+	query += " WHERE id = ?"
+
+	args = append(args, raceId)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	races, err := scanRaces(rows, now, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(races) == 0 {
+		return nil, fmt.Errorf("%w: race %d", ErrNotFound, raceId)
+	}
+
+	// If there is more than one race with the same ID, return only the first.
+	return races[0], nil
+}
+
+func (r *racesRepo) BatchGet(in *racing.BatchGetRacesRequest) ([]*racing.Race, []int64, error) {
+	if len(in.RaceIds) > maxBatchGet {
+		return nil, nil, invalidField("race_ids", "at most %d races may be fetched at once", maxBatchGet)
+	}
+
+	if len(in.RaceIds) == 0 {
+		return nil, nil, nil
+	}
+
+	now, err := r.now(in.GetAsOf())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	args := make([]interface{}, len(in.RaceIds))
+	for i, id := range in.RaceIds {
+		args[i] = id
+	}
+
+	rows, err := r.db.Query(getRaceQueries()[racesList]+" WHERE id IN ("+strings.Repeat("?,", len(args)-1)+"?)", args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	found, err := scanRaces(rows, now, raceColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byId := make(map[int64]*racing.Race, len(found))
+	for _, race := range found {
+		byId[race.Id] = race
+	}
+
+	var (
+		races   []*racing.Race
+		missing []int64
+		seen    = make(map[int64]bool)
+	)
+	for _, id := range in.RaceIds {
+		if race, ok := byId[id]; ok {
+			races = append(races, race)
+			continue
+		}
+
+		if !seen[id] {
+			missing = append(missing, id)
+			seen[id] = true
+		}
+	}
+
+	if len(missing) > 0 && !in.AllowMissing {
+		return nil, nil, fmt.Errorf("%w: races %s", ErrNotFound, joinIds(missing))
+	}
+
+	return races, missing, nil
+}
+
+// joinIds renders IDs as a comma separated list.
+func joinIds(ids []int64) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.FormatInt(id, 10)
+	}
+
+	return strings.Join(s, ", ")
+}
+
+func (r *racesRepo) TransitionRace(raceId int64, status racing.RaceStatus, etag string, audit Audit) (*racing.Race, error) {
+	if !validRaceStatus(status) {
+		return nil, invalidField("status", "unknown status %q", status)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := r.clock.Now()
+	stored, err := getStoredRace(tx, raceId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Repeating the current status is allowed and changes nothing, so
+	// transitions can be retried, though not with a stale etag.
+	race := deriveStatus(stored, now)
+	if race.Status == status {
+		if err := checkEtag(stored, etag); err != nil {
+			return nil, err
+		}
+		return race, nil
+	}
+
+	if !canTransition(race.Status, status) {
+		return nil, fmt.Errorf("%w: race %d cannot move from %s to %s", ErrFailedPrecondition, raceId, race.Status, status)
+	}
+
+	if err := writeRace(tx, raceId, etag, getRaceQueries()[racesUpdateStatus], status.String()); err != nil {
+		return nil, err
+	}
+
+	transitioned, err := getStoredRace(tx, raceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordRaceChange(tx, stored, transitioned, audit, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return deriveStatus(transitioned, now), nil
+}
+
+func (r *racesRepo) NextToJump(in *racing.ListNextToJumpRequest) ([]*racing.NextToJump, error) {
+	now, err := r.now(in.GetAsOf())
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(in.Limit)
+	switch {
+	case limit < 0:
+		return nil, invalidField("limit", "must not be negative")
+	case limit == 0:
+		limit = defaultNextToJump
+	case limit > maxNextToJump:
+		limit = maxNextToJump
+	}
+
+	if in.MaxPerMeeting < 0 {
+		return nil, invalidField("max_per_meeting", "must not be negative")
+	}
+
+	// Races are open up to and including their advertised start time. They
+	// are filtered on category before being ranked within their meeting, so
+	// that races of other categories do not count towards its cap.
+	var (
+		category string
+		args     = []interface{}{formatTime(now)}
+	)
+
+	if len(in.Categories) > 0 {
+		category = " AND category IN (" + strings.Repeat("?,", len(in.Categories)-1) + "?)"
+
+		for _, category := range in.Categories {
+			if !validRaceCategory(category) {
+				return nil, invalidField("categories", "unknown category %q", category)
+			}
+			args = append(args, category.String())
+		}
+	}
+
+	query := fmt.Sprintf(getRaceQueries()[racesNextToJump], category)
+	if in.MaxPerMeeting > 0 {
+		query += " WHERE meeting_rank <= ?"
+		args = append(args, in.MaxPerMeeting)
+	}
+
+	query += " ORDER BY julianday(advertised_start_time), id LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	races, err := scanRaces(rows, now, raceColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	var next []*racing.NextToJump
+	for _, race := range races {
+		next = append(next, &racing.NextToJump{
+			Race:          race,
+			SecondsToJump: int64(race.AdvertisedStartTime.AsTime().Sub(now) / time.Second),
+		})
+	}
+
+	return next, nil
+}
+
+func (r *racesRepo) Create(race *racing.Race, audit Audit) (*racing.Race, error) {
+	if race == nil {
+		return nil, invalidField("race", "is required")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	values, err := r.validateNew(tx, race)
+	if err != nil {
+		return nil, nestField("race", err)
+	}
+
+	res, err := tx.Exec(
+		getRaceQueries()[racesInsert],
+		values["meeting_id"],
+		values["name"],
+		values["number"],
+		values["visible"],
+		values["advertised_start_time"],
+		racing.RaceStatus_OPEN.String(),
+		values["category"],
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	now := r.clock.Now()
+	created, err := getStoredRace(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordRaceChange(tx, nil, created, audit, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return deriveStatus(created, now), nil
+}
+
+func (r *racesRepo) Update(race *racing.Race, paths []string, audit Audit) (*racing.Race, error) {
+	if race == nil {
+		return nil, invalidField("race", "is required")
+	}
+
+	if len(paths) == 0 {
+		return nil, invalidField("update_mask", "is required")
+	}
+
+	for _, path := range paths {
+		if _, ok := raceUpdateColumns[path]; !ok {
+			return nil, invalidField("update_mask", "field %q cannot be updated", path)
+		}
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := r.clock.Now()
+	existing, err := getStoredRace(tx, race.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.validate(tx, race, paths)
+	if err != nil {
+		return nil, nestField("race", err)
+	}
+
+	var (
+		sets = []string{"version = version + 1"}
+		args []interface{}
+	)
+	for _, path := range paths {
+		if _, ok := values[path]; !ok {
+			continue
+		}
+		sets = append(sets, raceUpdateColumns[path]+" = ?")
+		args = append(args, values[path])
+
+		// A path named twice is only set once.
+		delete(values, path)
+	}
+	if err := writeRace(tx, race.Id, race.Etag, `UPDATE races SET `+strings.Join(sets, ", ")+` WHERE id = ?`, args...); err != nil {
+		return nil, err
+	}
+
+	updated, err := getStoredRace(tx, race.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordRaceChange(tx, existing, updated, audit, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return deriveStatus(updated, now), nil
+}
+
+func (r *racesRepo) Import(races []*racing.Race, dryRun bool, audit Audit) (*ImportResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var (
+		result = &ImportResult{Errors: make(map[int]error)}
+		seen   = make(map[int64]bool)
+		now    = r.clock.Now()
+	)
+
+	for i, race := range races {
+		if race.GetId() < 1 {
+			result.Errors[i] = invalidField("id", "must be positive")
+			continue
+		}
+
+		if seen[race.Id] {
+			result.Errors[i] = invalidField("id", "race %d is imported more than once", race.Id)
+			continue
+		}
+		seen[race.Id] = true
+
+		values, err := r.validateNew(tx, race)
+		if errors.Is(err, ErrInvalidArgument) {
+			result.Errors[i] = err
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		existing, err := getStoredRace(tx, race.Id)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+
+		// Races are upserted even in a dry run, so that later rows are
+		// validated against earlier ones, and then rolled back.
+		res, err := tx.Exec(
+			getRaceQueries()[racesUpsert],
+			race.Id,
+			values["meeting_id"],
+			values["name"],
+			values["number"],
+			values["visible"],
+			values["advertised_start_time"],
+			racing.RaceStatus_OPEN.String(),
+			values["category"],
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case existing == nil:
+			result.Created++
+		case n > 0:
+			result.Updated++
+		default:
+			result.Unchanged++
+			continue
+		}
+
+		imported, err := getStoredRace(tx, race.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := recordRaceChange(tx, existing, imported, audit, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if dryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	result.Committed = true
+
+	return result, nil
+}
+
+func (r *racesRepo) Delete(raceId int64, etag string, audit Audit) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := r.clock.Now()
+	race, err := getStoredRace(tx, raceId)
+	if err != nil {
+		return err
+	}
+
+	// Results are kept as a record of what was paid out.
+	var resulted bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM results WHERE race_id = ?)`, raceId).Scan(&resulted); err != nil {
+		return err
+	}
+	if resulted {
+		return fmt.Errorf("%w: race %d has a recorded result", ErrFailedPrecondition, raceId)
+	}
+
+	if err := writeRace(tx, raceId, etag, `DELETE FROM races WHERE id = ?`); err != nil {
+		return err
+	}
+
+	for _, stmt := range []string{
+		`DELETE FROM price_history WHERE race_id = ?`,
+		`DELETE FROM prices WHERE race_id = ?`,
+		`DELETE FROM markets WHERE race_id = ?`,
+		`DELETE FROM runners WHERE race_id = ?`,
+	} {
+		if _, err := tx.Exec(stmt, raceId); err != nil {
+			return err
+		}
+	}
+
+	if err := recordRaceChange(tx, race, nil, audit, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *racesRepo) Archive(olderThan time.Duration, limit int, audit Audit) ([]int64, error) {
+	if err := validateArchive(olderThan, limit); err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Races are chosen on their persisted status, as only a FINAL or
+	// ABANDONED race has finished, however long ago it was due to start,
+	// and read as stored, for their history.
+	now := r.clock.Now()
+	rows, err := tx.Query(
+		getRaceQueries()[racesList]+` WHERE archived_time IS NULL AND status IN (?, ?) AND julianday(advertised_start_time) < julianday(?) ORDER BY julianday(advertised_start_time), id LIMIT ?`,
+		racing.RaceStatus_FINAL.String(),
+		racing.RaceStatus_ABANDONED.String(),
+		formatTime(now.Add(-olderThan)),
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	races, err := scanRaces(rows, time.Time{}, raceColumns)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(races))
+	for _, race := range races {
+		if err := writeRace(tx, race.Id, "", getRaceQueries()[racesArchive], formatTime(now)); err != nil {
+			return nil, err
+		}
+
+		archived, err := getStoredRace(tx, race.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := recordRaceChange(tx, race, archived, audit, now); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, race.Id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 
-	GetRaceById(raceId int64) (*racing.Race, error)
+	return ids, nil
 }
 
-type racesRepo struct {
-	db   *sql.DB
-	init sync.Once
+// validateArchive checks the age and batch size races are archived with.
+func validateArchive(olderThan time.Duration, limit int) error {
+	switch {
+	case olderThan <= 0:
+		return fmt.Errorf("%w: archive age must be positive", ErrInvalidArgument)
+	case limit < 1:
+		return fmt.Errorf("%w: archive limit must be positive", ErrInvalidArgument)
+	}
+
+	return nil
 }
 
-// NewRacesRepo creates a new races repository.
-func NewRacesRepo(db *sql.DB) RacesRepo {
-	return &racesRepo{db: db}
+// checkEtag checks, as writeRace does, that etag, if given, names the
+// current version of race.
+func checkEtag(race *racing.Race, etag string) error {
+	if etag == "" {
+		return nil
+	}
+
+	version, err := strconv.ParseInt(etag, 10, 64)
+	if err != nil || strconv.FormatInt(version, 10) != race.Etag {
+		return fmt.Errorf("%w: etag %q is not current for race %d", ErrAborted, etag, race.Id)
+	}
+
+	return nil
 }
 
-// Init prepares the race repository dummy data.
-func (r *racesRepo) Init() error {
-	var err error
+// writeRace executes a statement against a single race, whose WHERE clause
+// matches on the race's ID, taken as the last of args. If etag is given, the
+// statement only applies while the race is at the version it was issued for.
+func writeRace(tx *sql.Tx, raceId int64, etag string, query string, args ...interface{}) error {
+	args = append(args, raceId)
+
+	if etag != "" {
+		version, err := strconv.ParseInt(etag, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: etag %q is not current for race %d", ErrAborted, etag, raceId)
+		}
 
-	r.init.Do(func() {
-		// For test/example purposes, we seed the DB with some dummy races.
-		err = r.seed()
-	})
+		query += " AND version = ?"
+		args = append(args, version)
+	}
+
+	res, err := tx.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case n == 0 && etag != "":
+		return fmt.Errorf("%w: etag %q is not current for race %d", ErrAborted, etag, raceId)
+	case n == 0:
+		return fmt.Errorf("%w: race %d", ErrNotFound, raceId)
+	}
 
-	return err
+	return nil
 }
 
-func (r *racesRepo) GetRaceById(raceId int64) (*racing.Race, error) {
-	var (
-		err   error
-		query string
-		args  []interface{}
-	)
+// validateNew checks every field of a race being created, returning the
+// values to store for each. Races without a category take their meeting's.
+func (r *racesRepo) validateNew(tx *sql.Tx, race *racing.Race) (map[string]interface{}, error) {
+	paths := []string{"meeting_id", "name", "number", "visible", "advertised_start_time"}
+	if race.GetCategory() != racing.RaceCategory_CATEGORY_UNSPECIFIED {
+		paths = append(paths, "category")
+	}
 
-	query = getRaceQueries()[racesList]
-	query += " WHERE id = ?"
+	values, err := r.validate(tx, race, paths)
+	if err != nil {
+		return nil, err
+	}
 
-	args = append(args, raceId)
+	if _, ok := values["category"]; !ok {
+		var category string
+		if err := tx.QueryRow(`SELECT category FROM meetings WHERE id = ?`, race.MeetingId).Scan(&category); err != nil {
+			return nil, err
+		}
+		values["category"] = category
+	}
 
-	rows, err := r.db.Query(query, args...)
+	return values, nil
+}
+
+// validate checks the fields of a race named by paths, returning the values
+// to store for each, keyed by path.
+func (r *racesRepo) validate(tx *sql.Tx, race *racing.Race, paths []string) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+
+	for _, path := range paths {
+		switch path {
+		case "meeting_id":
+			var exists bool
+			if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM meetings WHERE id = ?)`, race.MeetingId).Scan(&exists); err != nil {
+				return nil, err
+			}
+			if !exists {
+				return nil, invalidField("meeting_id", "meeting %d does not exist", race.MeetingId)
+			}
+			values[path] = race.MeetingId
+		case "name":
+			name := strings.TrimSpace(race.Name)
+			if name == "" {
+				return nil, invalidField("name", "is required")
+			}
+			values[path] = name
+		case "number":
+			if race.Number < 1 {
+				return nil, invalidField("number", "must be positive")
+			}
+			values[path] = race.Number
+		case "visible":
+			values[path] = race.Visible
+		case "advertised_start_time":
+			if race.AdvertisedStartTime == nil {
+				return nil, invalidField("advertised_start_time", "is required")
+			}
+			t, err := ptypes.Timestamp(race.AdvertisedStartTime)
+			if err != nil {
+				return nil, invalidField("advertised_start_time", "%s", err)
+			}
+			values[path] = formatTime(t)
+		case "category":
+			if !validRaceCategory(race.Category) {
+				return nil, invalidField("category", "unknown category %q", race.Category)
+			}
+			values[path] = race.Category.String()
+		}
+	}
+
+	return values, nil
+}
+
+func (r *racesRepo) now(asOf *timestamp.Timestamp) (time.Time, error) {
+	return statusInstant(r.clock, asOf)
+}
+
+// statusInstant returns the instant race status is derived at, being asOf if
+// given or else the time of clock. It is truncated to the millisecond
+// precision SQLite compares times at.
+func statusInstant(clock Clock, asOf *timestamp.Timestamp) (time.Time, error) {
+	if asOf == nil {
+		return clock.Now().Truncate(time.Millisecond), nil
+	}
+
+	t, err := ptypes.Timestamp(asOf)
+	if err != nil {
+		return time.Time{}, invalidField("as_of", "%s", err)
+	}
+
+	return t.Truncate(time.Millisecond), nil
+}
+
+// getRace fetches a single race within a transaction, returning ErrNotFound
+// if there is no race with the ID.
+func getRace(tx *sql.Tx, raceId int64, now time.Time) (*racing.Race, error) {
+	rows, err := tx.Query(getRaceQueries()[racesList]+" WHERE id = ?", raceId)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	races, err := r.scanRaces(rows)
-	// If there is an error, or no races match this ID, return.
-	if err != nil || len(races) == 0 {
+	races, err := scanRaces(rows, now, raceColumns)
+	if err != nil {
 		return nil, err
 	}
 
-	// If there is more than one race with the same ID, return only the first.
+	if len(races) == 0 {
+		return nil, fmt.Errorf("%w: race %d", ErrNotFound, raceId)
+	}
+
 	return races[0], nil
 }
 
-func (r *racesRepo) List(filter *racing.ListRacesRequestFilter) ([]*racing.Race, error) {
-	var (
-		err   error
-		query string
-		args  []interface{}
-	)
+// getStoredRace fetches a race within a transaction as it is stored, with
+// its persisted status, as its history records it.
+func getStoredRace(tx *sql.Tx, raceId int64) (*racing.Race, error) {
+	return getRace(tx, raceId, time.Time{})
+}
+
+// deriveStatus returns a copy of a race read as stored, with its status
+// derived at now, as it is returned to callers.
+func deriveStatus(stored *racing.Race, now time.Time) *racing.Race {
+	var advertisedStart time.Time
+	if stored.AdvertisedStartTime != nil {
+		advertisedStart = stored.AdvertisedStartTime.AsTime()
+	}
+
+	race := proto.Clone(stored).(*racing.Race)
+	race.Status = effectiveStatus(stored.Status, advertisedStart, now)
+
+	return race
+}
+
+func (r *racesRepo) List(in *racing.ListRacesRequest) ([]*racing.Race, string, error) {
+	terms, err := raceOrderTerms(in.GetOrderBy())
+	if err != nil {
+		return nil, "", err
+	}
+
+	size, err := pageSize(in.GetPageSize())
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Status is derived relative to a single instant, shared by the status
+	// filter and scanRaces so the two always agree.
+	now, err := r.now(in.GetAsOf())
+	if err != nil {
+		return nil, "", err
+	}
+
+	clauses, args, err := r.applyFilter(in.GetFilter(), now)
+	if err != nil {
+		return nil, "", err
+	}
+
+	columns, err := readColumns(in.GetReadMask(), terms)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Resume after the last race of the previous page, if there was one.
+	checksum := pageChecksum(in.GetFilter(), terms)
+	if in.GetPageToken() != "" {
+		keys, err := decodePageToken(in.GetPageToken(), checksum)
+		if err != nil {
+			return nil, "", err
+		}
+
+		clause, keyArgs, err := keysetClause(terms, keys)
+		if err != nil {
+			return nil, "", err
+		}
 
-	query = getRaceQueries()[racesList]
+		clauses = append(clauses, clause)
+		args = append(args, keyArgs...)
+	}
+
+	query := selectRaces(columns)
+	if len(clauses) != 0 {
+		query += " WHERE " + strings.Join(clauses, " AND ")
+	}
 
-	query, args = r.applyFilter(query, filter)
+	// Fetch one more race than requested to learn whether a next page exists.
+	query += orderClause(terms) + " LIMIT ?"
+	args = append(args, size+1)
 
 	rows, err := r.db.Query(query, args...)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	races, err := scanRaces(rows, now, columns)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextPageToken string
+	if len(races) > size {
+		races = races[:size]
+
+		nextPageToken, err = page.EncodeToken(checksum, orderKeys(terms, races[size-1]))
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return races, nextPageToken, nil
+}
+
+func (r *racesRepo) Search(in *racing.SearchRacesRequest) ([]*racing.RaceSearchResult, string, error) {
+	match := search.MatchQuery(in.GetQuery())
+	if match == "" {
+		return nil, "", invalidField("query", "must contain a word to search for")
+	}
+
+	size, err := pageSize(in.GetPageSize())
+	if err != nil {
+		return nil, "", err
+	}
+
+	now, err := r.now(in.GetAsOf())
+	if err != nil {
+		return nil, "", err
+	}
+
+	clauses, filterArgs, err := r.applyFilter(in.GetFilter(), now)
+	if err != nil {
+		return nil, "", err
+	}
+	args := append([]interface{}{search.SnippetTokens, match}, filterArgs...)
+
+	// Results are always ordered by score then ID, so a page token records
+	// the score and ID of the last result returned. The query is part of the
+	// checksum, as a token is only meaningful against the same matches.
+	checksum := pageChecksum(&racing.SearchRacesRequest{Query: in.GetQuery(), Filter: in.GetFilter()}, nil)
+	if in.GetPageToken() != "" {
+		keys, err := decodePageToken(in.GetPageToken(), checksum)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var (
+			score float64
+			id    int64
+		)
+		if err := page.DecodeKeys(keys, &score, &id); err != nil {
+			return nil, "", invalidPageToken(err)
+		}
+
+		clauses = append(clauses, "(score > ? OR (score = ? AND id > ?))")
+		args = append(args, score, score, id)
+	}
+
+	query := getRaceQueries()[racesSearch]
+	if len(clauses) != 0 {
+		query += " WHERE " + strings.Join(clauses, " AND ")
+	}
+
+	// bm25 scores are lower the more relevant the match. Fetch one more
+	// result than requested to learn whether a next page exists.
+	query += " ORDER BY score ASC, id ASC LIMIT ?"
+	args = append(args, size+1)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var (
+		results []*racing.RaceSearchResult
+		scores  []float64
+	)
+	for rows.Next() {
+		var (
+			snippet string
+			score   float64
+		)
+
+		race, err := scanRace(rows, now, raceColumns, &snippet, &score)
+		if err != nil {
+			return nil, "", err
+		}
+
+		// Scores are negated for callers, so that higher is more relevant.
+		results = append(results, &racing.RaceSearchResult{Race: race, Snippet: snippet, Score: -score})
+		scores = append(scores, score)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	var nextPageToken string
+	if len(results) > size {
+		results = results[:size]
+
+		nextPageToken, err = page.EncodeToken(checksum, []interface{}{scores[size-1], results[size-1].Race.Id})
+		if err != nil {
+			return nil, "", err
+		}
 	}
 
-	return r.scanRaces(rows)
+	return results, nextPageToken, nil
 }
 
-func (r *racesRepo) applyFilter(query string, filter *racing.ListRacesRequestFilter) (string, []interface{}) {
-	illegalCharFlag := false
+// applyFilter converts a filter into SQL WHERE conditions and their args.
+// Race status is derived relative to now.
+func (r *racesRepo) applyFilter(filter *racing.ListRacesRequestFilter, now time.Time) ([]string, []interface{}, error) {
 	var (
 		clauses []string
 		args    []interface{}
 	)
 
+	// Archived races are left out unless asked for, which also lets the
+	// partial index on the start times of races not archived be used.
+	if !filter.GetIncludeArchived() {
+		clauses = append(clauses, "archived_time IS NULL")
+	}
+
 	if filter == nil {
-		return query, args
+		return clauses, args, nil
+	}
+
+	if len(filter.Ids) > 0 {
+		clauses = append(clauses, "id IN ("+strings.Repeat("?,", len(filter.Ids)-1)+"?)")
+
+		for _, id := range filter.Ids {
+			args = append(args, id)
+		}
 	}
 
 	if len(filter.MeetingIds) > 0 {
@@ -117,82 +1116,152 @@ func (r *racesRepo) applyFilter(query string, filter *racing.ListRacesRequestFil
 		args = append(args, true)
 	}
 
-	if len(clauses) != 0 {
-		query += " WHERE " + strings.Join(clauses, " AND ")
+	// Start times are compared as instants, as they are when ordering.
+	var from, to time.Time
+	if filter.AdvertisedStartFrom != nil {
+		t, err := ptypes.Timestamp(filter.AdvertisedStartFrom)
+		if err != nil {
+			return nil, nil, invalidField("filter.advertised_start_from", "%s", err)
+		}
+		from = t
+
+		clauses = append(clauses, "julianday(advertised_start_time) >= julianday(?)")
+		args = append(args, formatTime(from))
+	}
+
+	if filter.AdvertisedStartTo != nil {
+		t, err := ptypes.Timestamp(filter.AdvertisedStartTo)
+		if err != nil {
+			return nil, nil, invalidField("filter.advertised_start_to", "%s", err)
+		}
+		to = t
+
+		clauses = append(clauses, "julianday(advertised_start_time) < julianday(?)")
+		args = append(args, formatTime(to))
+	}
+
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return nil, nil, invalidField("filter.advertised_start_from", "must be before advertised_start_to")
+	}
+
+	if filter.LocalDate != "" {
+		clause, dateArgs, err := r.localDateClause(filter.LocalDate)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		clauses = append(clauses, clause)
+		args = append(args, dateArgs...)
+	}
+
+	// Statuses are matched against the derived status of each race, so
+	// open races whose start time has passed count as closed.
+	if len(filter.Statuses) > 0 {
+		clauses = append(clauses, "("+raceStatusExpr+") IN ("+strings.Repeat("?,", len(filter.Statuses)-1)+"?)")
+		args = append(args, formatTime(now))
+
+		for _, status := range filter.Statuses {
+			if !validRaceStatus(status) {
+				return nil, nil, invalidField("filter.statuses", "unknown status %q", status)
+			}
+			args = append(args, status.String())
+		}
+	}
+
+	if filter.NameContains != "" {
+		clauses = append(clauses, `name LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+likeEscaper.Replace(filter.NameContains)+"%")
+	}
+
+	if len(filter.Numbers) > 0 {
+		clauses = append(clauses, "number IN ("+strings.Repeat("?,", len(filter.Numbers)-1)+"?)")
+
+		for _, number := range filter.Numbers {
+			args = append(args, number)
+		}
 	}
 
-	query += " ORDER BY "
+	if filter.ResultedOnly {
+		clauses = append(clauses, "id IN (SELECT race_id FROM results)")
+	}
 
-	orderByStr := "advertised_start_time"
+	if len(filter.Categories) > 0 {
+		clauses = append(clauses, "category IN ("+strings.Repeat("?,", len(filter.Categories)-1)+"?)")
 
-	// Function to check if an illegal character is entered by the user.
-	strContainsIllegalChar := func(str string) bool {
-		for i := 0; i < len(str); i++ {
-			if str[i] == byte(';') {
-				return true
+		for _, category := range filter.Categories {
+			if !validRaceCategory(category) {
+				return nil, nil, invalidField("filter.categories", "unknown category %q", category)
 			}
+			args = append(args, category.String())
 		}
-		return false
 	}
 
-	/*	If the user supplied string can is a key that can be sorted by; if
-		no sort_by field is supplied, order will default to by advertised_start_time.
-		In the event a value that cannot be sorted by is supplied, the code already
-		return an error code 2 for unknown strings, and code 3 for unsupported data
-		types.
-	*/
-	if s := strings.ToLower(filter.SortBy); len(s) > 0 {
-		query += s
-	} else if strContainsIllegalChar(s) {
-		illegalCharFlag = true
-		query += orderByStr
-	} else {
-		query += orderByStr
+	return clauses, args, nil
+}
+
+// localDateClause returns the condition matching races due to start on a
+// date local to their venue, and its args. The date begins and ends at a
+// different instant in each time zone, so races are matched zone by zone,
+// with those of meetings without a time zone matched in UTC.
+func (r *racesRepo) localDateClause(localDate string) (string, []interface{}, error) {
+	date, err := time.Parse(meetingDateLayout, localDate)
+	if err != nil {
+		return "", nil, invalidField("filter.local_date", "must be formatted YYYY-MM-DD")
 	}
 
-	/*	Since this is a binary option of ascending or descending order
-		the code could be made more efficient by making sort_by a boolean
-		rather than a string. However, it will make it easier for users if
-		they can specify in natural language rather than 0 or 1. The default
-		order, if not specified will be descending, making the most recent
-		races appear first.
-	*/
+	rows, err := r.db.Query(`SELECT DISTINCT time_zone FROM meetings WHERE time_zone != '' ORDER BY time_zone`)
+	if err != nil {
+		return "", nil, err
+	}
+	defer rows.Close()
 
-	if s := strings.ToUpper(filter.Order); s == "ASC" || s == "ASCENDING" {
-		query += " ASC"
-	} else if strContainsIllegalChar(s) {
-		illegalCharFlag = true
-		query += " DESC"
-	} else {
-		query += " DESC"
+	var timeZones []string
+	for rows.Next() {
+		var timeZone string
+		if err := rows.Scan(&timeZone); err != nil {
+			return "", nil, err
+		}
+		timeZones = append(timeZones, timeZone)
 	}
+	if err := rows.Err(); err != nil {
+		return "", nil, err
+	}
+
+	const during = "julianday(advertised_start_time) >= julianday(?) AND julianday(advertised_start_time) < julianday(?)"
+
+	var (
+		clauses []string
+		args    []interface{}
+	)
+	for _, timeZone := range append(timeZones, "") {
+		from, to, err := localDay(date, timeZone)
+		if err != nil {
+			return "", nil, fmt.Errorf("loading time zone %q: %w", timeZone, err)
+		}
 
-	/* 	Not really possible, from my testing, to get extraneous SQL commands to
-	execute on the server, but given the order_by part allows user input with errors
-	handled higher up it might potentially be possible for a malicious user to sneak
-	a semi-colon in and run a distinct SQL command, especially if the order of code
-	is moved around throughout development. Probably not necessary, but worth noting
-	something to do with security, especially since the API uses POST instead of GET.
-	*/
-	if illegalCharFlag {
-		// Do something  useful here, like flag user.
-		{
+		if timeZone == "" {
+			clauses = append(clauses, "(meeting_id NOT IN (SELECT id FROM meetings WHERE time_zone != '') AND "+during+")")
+			args = append(args, formatTime(from), formatTime(to))
+			continue
 		}
+
+		clauses = append(clauses, "(meeting_id IN (SELECT id FROM meetings WHERE time_zone = ?) AND "+during+")")
+		args = append(args, timeZone, formatTime(from), formatTime(to))
 	}
 
-	return query, args
+	return "(" + strings.Join(clauses, " OR ") + ")", args, nil
 }
 
-func (m *racesRepo) scanRaces(
+func scanRaces(
 	rows *sql.Rows,
+	now time.Time,
+	columns []string,
 ) ([]*racing.Race, error) {
 	var races []*racing.Race
 
 	for rows.Next() {
-		var race racing.Race
-		var advertisedStart time.Time
-
-		if err := rows.Scan(&race.Id, &race.MeetingId, &race.Name, &race.Number, &race.Visible, &advertisedStart); err != nil {
+		race, err := scanRace(rows, now, columns)
+		if err != nil {
 			if err == sql.ErrNoRows {
 				return nil, nil
 			}
@@ -200,6 +1269,60 @@ func (m *racesRepo) scanRaces(
 			return nil, err
 		}
 
+		races = append(races, race)
+	}
+
+	return races, rows.Err()
+}
+
+// scanRace scans the race at the current row, whose columns are those given,
+// in the order of raceColumns, followed by any into extra. Fields not read
+// from any of the columns are left unset.
+func scanRace(rows *sql.Rows, now time.Time, columns []string, extra ...interface{}) (*racing.Race, error) {
+	var race racing.Race
+	var (
+		advertisedStart time.Time
+		status          string
+		version         int64
+		category        string
+		archived        sql.NullTime
+		timeZone        sql.NullString
+		zoned           bool
+	)
+
+	dest := make([]interface{}, 0, len(columns)+len(extra))
+	for _, column := range columns {
+		switch column {
+		case "id":
+			dest = append(dest, &race.Id)
+		case "meeting_id":
+			dest = append(dest, &race.MeetingId)
+		case "name":
+			dest = append(dest, &race.Name)
+		case "number":
+			dest = append(dest, &race.Number)
+		case "visible":
+			dest = append(dest, &race.Visible)
+		case "advertised_start_time":
+			dest = append(dest, &advertisedStart)
+		case "status":
+			dest = append(dest, &status)
+		case "version":
+			dest = append(dest, &version)
+		case "category":
+			dest = append(dest, &category)
+		case "archived_time":
+			dest = append(dest, &archived)
+		case "time_zone":
+			dest, zoned = append(dest, &timeZone), true
+		}
+	}
+
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	if !advertisedStart.IsZero() {
 		ts, err := ptypes.TimestampProto(advertisedStart)
 		if err != nil {
 			return nil, err
@@ -207,19 +1330,83 @@ func (m *racesRepo) scanRaces(
 
 		race.AdvertisedStartTime = ts
 
-		/* 	Compare advertised start time of race to current timestamp.
-		If current time is after advertised start time, race is closed,
-		otherwise it is still open. All races in the databaseappear to
-		be circa 2021, so it is expected that they are all closed.
-		*/
-		if time.Now().After(time.Unix(ts.Seconds, int64(ts.Nanos))) {
-			race.Status = "CLOSED"
-		} else {
-			race.Status = "OPEN"
+		// Races of a meeting without a time zone, or of no meeting, are
+		// given in UTC.
+		if zoned {
+			if race.LocalStartTime, err = localStartTime(advertisedStart, timeZone.String); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if status != "" {
+		race.Status = effectiveStatus(racing.RaceStatus(racing.RaceStatus_value[status]), advertisedStart, now)
+	}
+
+	race.Category = racing.RaceCategory(racing.RaceCategory_value[category])
+
+	if archived.Valid {
+		ts, err := ptypes.TimestampProto(archived.Time)
+		if err != nil {
+			return nil, err
+		}
+
+		race.ArchivedTime = ts
+	}
+
+	// The etag is the version, which is opaque to callers.
+	if version != 0 {
+		race.Etag = strconv.FormatInt(version, 10)
+	}
+
+	return &race, nil
+}
+
+// readColumns resolves a read mask to the columns to select, being those the
+// named fields are read from along with those ordered on by terms, which
+// page tokens are made from. Every column is selected when there is no mask.
+func readColumns(mask *field_mask.FieldMask, terms []orderTerm) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		return raceColumns, nil
+	}
+
+	needed := make(map[string]bool)
+	for _, path := range mask.GetPaths() {
+		columns, ok := raceReadColumns[path]
+		if !ok {
+			return nil, invalidField("read_mask", "unknown field %q", path)
+		}
+
+		for _, column := range columns {
+			needed[column] = true
+		}
+	}
+
+	for _, term := range terms {
+		for _, column := range raceReadColumns[term.field] {
+			needed[column] = true
+		}
+	}
+
+	var columns []string
+	for _, column := range raceColumns {
+		if needed[column] {
+			columns = append(columns, column)
 		}
+	}
+
+	return columns, nil
+}
 
-		races = append(races, &race)
+// selectRaces returns a query selecting the given columns of races.
+func selectRaces(columns []string) string {
+	exprs := make([]string, len(columns))
+	for i, column := range columns {
+		exprs[i] = column
+		if column == "time_zone" {
+			exprs[i] = raceTimeZoneExpr
+		}
 	}
 
-	return races, nil
+	return fmt.Sprintf(getRaceQueries()[racesSelect], strings.Join(exprs, ", "))
 }
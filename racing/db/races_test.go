@@ -0,0 +1,127 @@
+package db_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/db/dbtest"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+func TestSQLiteRacesRepo(t *testing.T) {
+	// Without FTS5, the suite fails once here rather than in every test.
+	if _, err := migratedDB(t); err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+
+	dbtest.TestRacesRepo(t, func(t *testing.T, clock db.Clock, fixture db.RacesFixture) db.RacesRepo {
+		racingDB, err := migratedDB(t)
+		if err != nil {
+			t.Fatalf("migrating: %v", err)
+		}
+
+		if err := insertRacesFixture(racingDB, fixture); err != nil {
+			t.Fatalf("inserting fixture: %v", err)
+		}
+
+		return db.NewRacesRepo(racingDB, clock)
+	})
+}
+
+func TestMemoryRacesRepo(t *testing.T) {
+	dbtest.TestRacesRepo(t, func(t *testing.T, clock db.Clock, fixture db.RacesFixture) db.RacesRepo {
+		return db.NewMemoryRacesRepo(clock, fixture)
+	})
+}
+
+// migratedDB opens an empty database, closed at the end of the test, and
+// migrates it up to date. It fails the test if SQLite lacks FTS5.
+func migratedDB(t *testing.T) (*sql.DB, error) {
+	racingDB := emptyDB(t)
+	_, err := migrate(t, racingDB)
+	return racingDB, err
+}
+
+// emptyDB opens an empty database, closed at the end of the test.
+func emptyDB(t *testing.T) *sql.DB {
+	racingDB, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "racing.db"))
+	if err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+	t.Cleanup(func() { racingDB.Close() })
+
+	return racingDB
+}
+
+// migrate migrates a database up to date, returning the migrations applied.
+// It fails the test if SQLite lacks FTS5.
+func migrate(t *testing.T, racingDB *sql.DB) ([]db.MigrationStatus, error) {
+	// Search needs FTS5, without which the SQLite repository cannot be
+	// tested at all.
+	applied, err := db.Migrate(racingDB)
+	if err != nil && strings.Contains(err.Error(), "no such module: fts5") {
+		t.Fatalf("SQLite is built without FTS5; run the tests with -tags sqlite_fts5, as make test does: %v", err)
+	}
+	return applied, err
+}
+
+// insertRacesFixture stores a fixture as rows, as the in-memory repository
+// holds it.
+func insertRacesFixture(racingDB *sql.DB, fixture db.RacesFixture) error {
+	for _, meeting := range fixture.Meetings {
+		status := meeting.Status
+		if status == racing.Meeting_STATUS_UNSPECIFIED {
+			status = racing.Meeting_SCHEDULED
+		}
+
+		_, err := racingDB.Exec(`INSERT INTO meetings (id, name, country, date, category, status, time_zone) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			meeting.Id, meeting.Name, meeting.Country, meeting.Date, meeting.Category.String(), status.String(), meeting.TimeZone)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, race := range fixture.Races {
+		status := race.Status
+		if status == racing.RaceStatus_STATUS_UNSPECIFIED {
+			status = racing.RaceStatus_OPEN
+		}
+
+		version := int64(1)
+		if race.Etag != "" {
+			var err error
+			if version, err = strconv.ParseInt(race.Etag, 10, 64); err != nil {
+				return err
+			}
+		}
+
+		var archived interface{}
+		if race.ArchivedTime != nil {
+			archived = race.ArchivedTime.AsTime().UTC().Format(time.RFC3339Nano)
+		}
+
+		_, err := racingDB.Exec(`INSERT INTO races (id, meeting_id, name, number, visible, advertised_start_time, status, version, category, archived_time) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			race.Id, race.MeetingId, race.Name, race.Number, race.Visible,
+			race.AdvertisedStartTime.AsTime().UTC().Format(time.RFC3339Nano), status.String(), version, race.Category.String(), archived)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, raceId := range fixture.ResultedRaceIds {
+		_, err := racingDB.Exec(`INSERT INTO results (race_id, final, recorded_time) VALUES (?, 1, ?)`,
+			raceId, time.Now().UTC().Format(time.RFC3339Nano))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
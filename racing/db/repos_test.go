@@ -0,0 +1,68 @@
+package db_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/db/dbtest"
+)
+
+func TestSQLiteMeetingsRepo(t *testing.T) {
+	dbtest.TestMeetingsRepo(t, newSQLiteRepos)
+}
+
+func TestSQLiteRunnersRepo(t *testing.T) {
+	dbtest.TestRunnersRepo(t, newSQLiteRepos)
+}
+
+func TestSQLiteResultsRepo(t *testing.T) {
+	dbtest.TestResultsRepo(t, newSQLiteRepos)
+}
+
+func TestSQLitePricesRepo(t *testing.T) {
+	dbtest.TestPricesRepo(t, newSQLiteRepos)
+}
+
+// newSQLiteRepos creates every SQLite repository over a single migrated
+// database holding fixture.
+func newSQLiteRepos(t *testing.T, clock db.Clock, fixture dbtest.Fixture) dbtest.Repos {
+	racingDB, err := migratedDB(t)
+	if err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+
+	if err := insertRacesFixture(racingDB, fixture.RacesFixture); err != nil {
+		t.Fatalf("inserting fixture: %v", err)
+	}
+	if err := insertRunners(racingDB, fixture); err != nil {
+		t.Fatalf("inserting fixture: %v", err)
+	}
+
+	return dbtest.Repos{
+		Races:    db.NewRacesRepo(racingDB, clock),
+		Meetings: db.NewMeetingsRepo(racingDB),
+		Runners:  db.NewRunnersRepo(racingDB),
+		Results:  db.NewResultsRepo(racingDB),
+		Prices:   db.NewPricesRepo(racingDB),
+	}
+}
+
+// insertRunners stores the runners of a fixture as rows.
+func insertRunners(racingDB *sql.DB, fixture dbtest.Fixture) error {
+	for _, runner := range fixture.Runners {
+		var scratched interface{}
+		if runner.ScratchedTime != nil {
+			scratched = runner.ScratchedTime.AsTime().UTC().Format(time.RFC3339Nano)
+		}
+
+		_, err := racingDB.Exec(`INSERT INTO runners (id, race_id, number, name, barrier, weight, scratched, scratched_time, scratching_reason) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			runner.Id, runner.RaceId, runner.Number, runner.Name, runner.Barrier, runner.Weight, runner.Scratched, scratched, runner.ScratchingReason)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
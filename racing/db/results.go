@@ -0,0 +1,340 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/protobuf/proto"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// placedPositions is the number of positions place dividends are paid on.
+const placedPositions = 3
+
+// ResultsRepo provides repository access to race results.
+type ResultsRepo interface {
+	// Init will initialise our results repository.
+	Init() error
+
+	// Record will validate and store the result of a race, moving the race
+	// to INTERIM or FINAL and recording the move in its history, attributed
+	// to audit. Recording the result a race already has is a no-op.
+	Record(in *racing.RecordResultRequest, at time.Time, audit Audit) (*racing.Result, error)
+
+	// Get will return the result of a race, or ErrNotFound if it has none.
+	Get(raceId int64) (*racing.Result, error)
+}
+
+type resultsRepo struct {
+	db *sql.DB
+}
+
+// NewResultsRepo creates a new results repository.
+func NewResultsRepo(db *sql.DB) ResultsRepo {
+	return &resultsRepo{db: db}
+}
+
+// Init does nothing, as no results are seeded. The results tables are
+// created by migrations.
+func (r *resultsRepo) Init() error {
+	return nil
+}
+
+func (r *resultsRepo) Get(raceId int64) (*racing.Result, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := r.get(tx, raceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, fmt.Errorf("%w: race %d has no result", ErrNotFound, raceId)
+	}
+
+	return result, nil
+}
+
+func (r *resultsRepo) Record(in *racing.RecordResultRequest, at time.Time, audit Audit) (*racing.Result, error) {
+	// Placings and dividends are copied to be sorted, leaving the request
+	// as it was given.
+	result := &racing.Result{
+		RaceId:    in.RaceId,
+		Final:     in.Final,
+		Placings:  append([]*racing.Placing(nil), in.Placings...),
+		Dividends: append([]*racing.Dividend(nil), in.Dividends...),
+	}
+	sortResult(result)
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stored, err := getStoredRace(tx, in.RaceId)
+	if err != nil {
+		return nil, err
+	}
+	race := deriveStatus(stored, at)
+
+	existing, err := r.get(tx, in.RaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	// A retried request matching the stored result succeeds without change.
+	if existing != nil && sameResult(existing, result) {
+		return existing, nil
+	}
+
+	from := race.Status
+	to := racing.RaceStatus_INTERIM
+	if in.Final {
+		to = racing.RaceStatus_FINAL
+	}
+
+	// An interim result may be recorded again to correct it.
+	if !canTransition(from, to) && !(from == racing.RaceStatus_INTERIM && to == racing.RaceStatus_INTERIM) {
+		return nil, fmt.Errorf("%w: cannot record a result for race %d while it is %s", ErrFailedPrecondition, in.RaceId, from)
+	}
+
+	if err := r.validate(tx, result); err != nil {
+		return nil, err
+	}
+
+	for _, stmt := range []string{
+		`DELETE FROM result_placings WHERE race_id = ?`,
+		`DELETE FROM result_dividends WHERE race_id = ?`,
+	} {
+		if _, err := tx.Exec(stmt, in.RaceId); err != nil {
+			return nil, err
+		}
+	}
+
+	queries := getResultQueries()
+
+	if _, err := tx.Exec(queries[resultsUpsert], in.RaceId, in.Final, formatTime(at)); err != nil {
+		return nil, err
+	}
+
+	for _, placing := range result.Placings {
+		if _, err := tx.Exec(queries[resultsInsertPlacing], in.RaceId, placing.RunnerId, placing.Position); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, dividend := range result.Dividends {
+		if _, err := tx.Exec(queries[resultsInsertDividend], in.RaceId, dividend.BetType.String(), dividend.RunnerId, dividend.AmountCents); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.Exec(getRaceQueries()[racesUpdateStatus], to.String(), in.RaceId); err != nil {
+		return nil, err
+	}
+
+	resulted, err := getStoredRace(tx, in.RaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordRaceChange(tx, stored, resulted, audit, at); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	result.RecordedTime, err = ptypes.TimestampProto(at)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// validate checks a result against the runners of its race.
+func (r *resultsRepo) validate(tx *sql.Tx, result *racing.Result) error {
+	if len(result.Placings) == 0 {
+		return invalidField("placings", "a result requires at least one placing")
+	}
+
+	scratched, err := raceRunners(tx, result.RaceId)
+	if err != nil {
+		return err
+	}
+
+	positions := make(map[int64]int64)
+	for _, placing := range result.Placings {
+		s, ok := scratched[placing.RunnerId]
+		switch {
+		case !ok:
+			return invalidField("placings", "runner %d is not in race %d", placing.RunnerId, result.RaceId)
+		case s:
+			return invalidField("placings", "runner %d was scratched", placing.RunnerId)
+		case placing.Position < 1:
+			return invalidField("placings", "runner %d has position %d", placing.RunnerId, placing.Position)
+		}
+
+		if _, dup := positions[placing.RunnerId]; dup {
+			return invalidField("placings", "runner %d is placed more than once", placing.RunnerId)
+		}
+		positions[placing.RunnerId] = placing.Position
+	}
+
+	// Placings are sorted, so the first holds the best position.
+	if result.Placings[0].Position != 1 {
+		return invalidField("placings", "no runner is placed first")
+	}
+
+	type dividendKey struct {
+		betType  racing.Dividend_BetType
+		runnerID int64
+	}
+	seen := make(map[dividendKey]bool)
+
+	for _, dividend := range result.Dividends {
+		position, placed := positions[dividend.RunnerId]
+
+		switch dividend.BetType {
+		case racing.Dividend_WIN:
+			if position != 1 {
+				return invalidField("dividends", "win dividend for runner %d, which did not win", dividend.RunnerId)
+			}
+		case racing.Dividend_PLACE:
+			if !placed || position > placedPositions {
+				return invalidField("dividends", "place dividend for runner %d, which did not place", dividend.RunnerId)
+			}
+		default:
+			return invalidField("dividends", "unknown bet type %q", dividend.BetType)
+		}
+
+		if dividend.AmountCents <= 0 {
+			return invalidField("dividends", "dividend for runner %d must be positive", dividend.RunnerId)
+		}
+
+		key := dividendKey{dividend.BetType, dividend.RunnerId}
+		if seen[key] {
+			return invalidField("dividends", "more than one %s dividend for runner %d", dividend.BetType, dividend.RunnerId)
+		}
+		seen[key] = true
+	}
+
+	return nil
+}
+
+// get fetches the result of a race within a transaction, or nil if none.
+func (r *resultsRepo) get(tx *sql.Tx, raceId int64) (*racing.Result, error) {
+	queries := getResultQueries()
+
+	var (
+		result   racing.Result
+		recorded time.Time
+	)
+	err := tx.QueryRow(queries[resultsGet], raceId).Scan(&result.RaceId, &result.Final, &recorded)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.RecordedTime, err = ptypes.TimestampProto(recorded)
+	if err != nil {
+		return nil, err
+	}
+
+	placings, err := tx.Query(queries[resultsGetPlacings], raceId)
+	if err != nil {
+		return nil, err
+	}
+	defer placings.Close()
+
+	for placings.Next() {
+		var placing racing.Placing
+		if err := placings.Scan(&placing.RunnerId, &placing.Position); err != nil {
+			return nil, err
+		}
+		result.Placings = append(result.Placings, &placing)
+	}
+	if err := placings.Err(); err != nil {
+		return nil, err
+	}
+
+	dividends, err := tx.Query(queries[resultsGetDividends], raceId)
+	if err != nil {
+		return nil, err
+	}
+	defer dividends.Close()
+
+	for dividends.Next() {
+		var (
+			dividend racing.Dividend
+			betType  string
+		)
+		if err := dividends.Scan(&betType, &dividend.RunnerId, &dividend.AmountCents); err != nil {
+			return nil, err
+		}
+		dividend.BetType = racing.Dividend_BetType(racing.Dividend_BetType_value[betType])
+		result.Dividends = append(result.Dividends, &dividend)
+	}
+	if err := dividends.Err(); err != nil {
+		return nil, err
+	}
+
+	sortResult(&result)
+
+	return &result, nil
+}
+
+// sortResult puts placings and dividends into their canonical order.
+func sortResult(result *racing.Result) {
+	sort.SliceStable(result.Placings, func(i, j int) bool {
+		a, b := result.Placings[i], result.Placings[j]
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.RunnerId < b.RunnerId
+	})
+
+	sort.SliceStable(result.Dividends, func(i, j int) bool {
+		a, b := result.Dividends[i], result.Dividends[j]
+		if a.BetType != b.BetType {
+			return a.BetType < b.BetType
+		}
+		return a.RunnerId < b.RunnerId
+	})
+}
+
+// sameResult reports whether two sorted results record the same outcome,
+// regardless of when they were recorded.
+func sameResult(a, b *racing.Result) bool {
+	if a.RaceId != b.RaceId || a.Final != b.Final ||
+		len(a.Placings) != len(b.Placings) || len(a.Dividends) != len(b.Dividends) {
+		return false
+	}
+
+	for i := range a.Placings {
+		if !proto.Equal(a.Placings[i], b.Placings[i]) {
+			return false
+		}
+	}
+
+	for i := range a.Dividends {
+		if !proto.Equal(a.Dividends[i], b.Dividends[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,278 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/shared/page"
+)
+
+// RunnersRepo provides repository access to runners.
+type RunnersRepo interface {
+	// Init will initialise our runners repository.
+	Init() error
+
+	// List will return a page of runners, along with a token for the next
+	// page, or an empty token if there are no further runners.
+	List(in *racing.ListRunnersRequest) ([]*racing.Runner, string, error)
+
+	// ListByRace will return every runner in a race, ordered by number.
+	ListByRace(raceId int64) ([]*racing.Runner, error)
+
+	// Get will return a single runner, or ErrNotFound if none match the ID.
+	Get(runnerId int64) (*racing.Runner, error)
+
+	// Scratch will withdraw a runner from its race, recording when and why,
+	// and recording the withdrawal in the race's history, attributed to
+	// audit.
+	Scratch(runnerId int64, reason string, at time.Time, audit Audit) (*racing.Runner, error)
+}
+
+type runnersRepo struct {
+	db *sql.DB
+}
+
+// NewRunnersRepo creates a new runners repository.
+func NewRunnersRepo(db *sql.DB) RunnersRepo {
+	return &runnersRepo{db: db}
+}
+
+// Init does nothing, as dummy runners are only seeded by the seed command.
+// The runners table is created by migrations.
+func (r *runnersRepo) Init() error {
+	return nil
+}
+
+func (r *runnersRepo) Get(runnerId int64) (*racing.Runner, error) {
+	return r.get(r.db, runnerId)
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx.
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// raceRunners returns whether each runner of a race has been scratched,
+// keyed by runner ID.
+func raceRunners(q queryer, raceId int64) (map[int64]bool, error) {
+	rows, err := q.Query(`SELECT id, scratched FROM runners WHERE race_id = ?`, raceId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scratched := make(map[int64]bool)
+	for rows.Next() {
+		var (
+			id int64
+			s  bool
+		)
+		if err := rows.Scan(&id, &s); err != nil {
+			return nil, err
+		}
+		scratched[id] = s
+	}
+
+	return scratched, rows.Err()
+}
+
+func (r *runnersRepo) get(q queryer, runnerId int64) (*racing.Runner, error) {
+	rows, err := q.Query(getRunnerQueries()[runnersList]+" WHERE id = ?", runnerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runners, err := r.scanRunners(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(runners) == 0 {
+		return nil, fmt.Errorf("%w: runner %d", ErrNotFound, runnerId)
+	}
+
+	return runners[0], nil
+}
+
+func (r *runnersRepo) ListByRace(raceId int64) ([]*racing.Runner, error) {
+	rows, err := r.db.Query(getRunnerQueries()[runnersList]+" WHERE race_id = ? ORDER BY number ASC", raceId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanRunners(rows)
+}
+
+func (r *runnersRepo) List(in *racing.ListRunnersRequest) ([]*racing.Runner, string, error) {
+	size, err := pageSize(in.GetPageSize())
+	if err != nil {
+		return nil, "", err
+	}
+
+	clauses, args := r.applyFilter(in.GetFilter())
+
+	// Runners are always ordered by race then number, which together are
+	// unique, so a page token records the race and number of the last runner.
+	checksum := pageChecksum(in.GetFilter(), nil)
+	if in.GetPageToken() != "" {
+		keys, err := decodePageToken(in.GetPageToken(), checksum)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var raceID, number int64
+		if err := page.DecodeKeys(keys, &raceID, &number); err != nil {
+			return nil, "", invalidPageToken(err)
+		}
+
+		clauses = append(clauses, "(race_id > ? OR (race_id = ? AND number > ?))")
+		args = append(args, raceID, raceID, number)
+	}
+
+	query := getRunnerQueries()[runnersList]
+	if len(clauses) != 0 {
+		query += " WHERE " + strings.Join(clauses, " AND ")
+	}
+
+	// Fetch one more runner than requested to learn whether a next page exists.
+	query += " ORDER BY race_id ASC, number ASC LIMIT ?"
+	args = append(args, size+1)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	runners, err := r.scanRunners(rows)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextPageToken string
+	if len(runners) > size {
+		runners = runners[:size]
+
+		last := runners[size-1]
+		nextPageToken, err = page.EncodeToken(checksum, []interface{}{last.RaceId, last.Number})
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return runners, nextPageToken, nil
+}
+
+func (r *runnersRepo) Scratch(runnerId int64, reason string, at time.Time, audit Audit) (*racing.Runner, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, invalidField("reason", "a reason is required to scratch a runner")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	runner, err := r.get(tx, runnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if runner.Scratched {
+		return nil, fmt.Errorf("%w: runner %d is already scratched", ErrFailedPrecondition, runnerId)
+	}
+
+	// Runners cannot be withdrawn from a race that has been run or abandoned.
+	var raceStatus string
+	if err := tx.QueryRow(`SELECT status FROM races WHERE id = ?`, runner.RaceId).Scan(&raceStatus); err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	switch racing.RaceStatus(racing.RaceStatus_value[raceStatus]) {
+	case racing.RaceStatus_INTERIM, racing.RaceStatus_FINAL, racing.RaceStatus_ABANDONED:
+		return nil, fmt.Errorf("%w: race %d is %s", ErrFailedPrecondition, runner.RaceId, raceStatus)
+	}
+
+	if _, err := tx.Exec(getRunnerQueries()[runnersScratch], formatTime(at), reason, runnerId); err != nil {
+		return nil, err
+	}
+
+	scratched, err := r.get(tx, runnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = recordRaceFields(tx, runner.RaceId, []*racing.FieldChange{
+		{Field: runnerField(runnerId, "scratched"), OldValue: strconv.FormatBool(runner.Scratched), NewValue: strconv.FormatBool(scratched.Scratched)},
+		{Field: runnerField(runnerId, "scratching_reason"), OldValue: runner.ScratchingReason, NewValue: scratched.ScratchingReason},
+	}, audit, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return scratched, tx.Commit()
+}
+
+// applyFilter converts a filter into SQL WHERE conditions and their args.
+func (r *runnersRepo) applyFilter(filter *racing.ListRunnersRequestFilter) ([]string, []interface{}) {
+	var (
+		clauses []string
+		args    []interface{}
+	)
+
+	if filter == nil {
+		return clauses, args
+	}
+
+	if len(filter.RaceIds) > 0 {
+		clauses = append(clauses, "race_id IN ("+strings.Repeat("?,", len(filter.RaceIds)-1)+"?)")
+
+		for _, raceID := range filter.RaceIds {
+			args = append(args, raceID)
+		}
+	}
+
+	if filter.ExcludeScratched {
+		clauses = append(clauses, "scratched = ?")
+		args = append(args, false)
+	}
+
+	return clauses, args
+}
+
+func (r *runnersRepo) scanRunners(rows *sql.Rows) ([]*racing.Runner, error) {
+	var runners []*racing.Runner
+
+	for rows.Next() {
+		var (
+			runner        racing.Runner
+			scratchedTime sql.NullTime
+		)
+
+		if err := rows.Scan(&runner.Id, &runner.RaceId, &runner.Number, &runner.Name, &runner.Barrier, &runner.Weight, &runner.Scratched, &scratchedTime, &runner.ScratchingReason); err != nil {
+			return nil, err
+		}
+
+		if scratchedTime.Valid {
+			ts, err := ptypes.TimestampProto(scratchedTime.Time)
+			if err != nil {
+				return nil, err
+			}
+
+			runner.ScratchedTime = ts
+		}
+
+		runners = append(runners, &runner)
+	}
+
+	return runners, rows.Err()
+}
@@ -0,0 +1,50 @@
+package db
+
+import (
+	"time"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// raceTransitions lists, for each race status, the statuses a race may be
+// moved on to. A closed race may be declared FINAL without first being
+// INTERIM. FINAL and ABANDONED are terminal.
+var raceTransitions = map[racing.RaceStatus][]racing.RaceStatus{
+	racing.RaceStatus_OPEN:      {racing.RaceStatus_SUSPENDED, racing.RaceStatus_CLOSED, racing.RaceStatus_ABANDONED},
+	racing.RaceStatus_SUSPENDED: {racing.RaceStatus_OPEN, racing.RaceStatus_CLOSED, racing.RaceStatus_ABANDONED},
+	racing.RaceStatus_CLOSED:    {racing.RaceStatus_INTERIM, racing.RaceStatus_FINAL, racing.RaceStatus_ABANDONED},
+	racing.RaceStatus_INTERIM:   {racing.RaceStatus_FINAL, racing.RaceStatus_ABANDONED},
+}
+
+// canTransition reports whether a race may move from one status to another.
+func canTransition(from, to racing.RaceStatus) bool {
+	for _, status := range raceTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validRaceStatus reports whether status is a known, specified status.
+func validRaceStatus(status racing.RaceStatus) bool {
+	_, ok := racing.RaceStatus_name[int32(status)]
+	return ok && status != racing.RaceStatus_STATUS_UNSPECIFIED
+}
+
+// raceStatusExpr is the SQL equivalent of effectiveStatus, taking the
+// instant to evaluate at as its single argument.
+const raceStatusExpr = `CASE WHEN status = 'OPEN' AND julianday(advertised_start_time) < julianday(?) THEN 'CLOSED' ELSE status END`
+
+// effectiveStatus derives the status of a race from its persisted status.
+// An open race closes automatically once now is after its advertised start
+// time, without that being persisted. At the zero time, nothing is derived,
+// so that races read to record their history have their persisted status.
+func effectiveStatus(persisted racing.RaceStatus, advertisedStart, now time.Time) racing.RaceStatus {
+	if persisted == racing.RaceStatus_OPEN && !now.IsZero() && now.After(advertisedStart) {
+		return racing.RaceStatus_CLOSED
+	}
+
+	return persisted
+}
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"sync"
+	"time"
+)
+
+// locations caches the time zones loaded by loadLocation, keyed by name, as
+// loading one reads the time zone database.
+var locations sync.Map
+
+// loadLocation returns the IANA time zone with the given name, or UTC for an
+// empty name, being that of a venue whose time zone is unknown.
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+
+	return loc, nil
+}
+
+// localStartTime renders a start time in the named time zone as RFC3339 text,
+// carrying the zone's offset at that instant.
+func localStartTime(t time.Time, timeZone string) (string, error) {
+	loc, err := loadLocation(timeZone)
+	if err != nil {
+		return "", err
+	}
+
+	return t.In(loc).Format(time.RFC3339Nano), nil
+}
+
+// localDay returns the instants a date, as YYYY-MM-DD, begins and ends at in
+// the named time zone. Days are 23 or 25 hours long where daylight saving
+// starts or ends on them.
+func localDay(date time.Time, timeZone string) (time.Time, time.Time, error) {
+	loc, err := loadLocation(timeZone)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	next := date.AddDate(0, 0, 1)
+	return startOfDay(date, loc), startOfDay(next, loc), nil
+}
+
+// startOfDay returns the first instant of date in loc. Where daylight saving
+// starts at midnight, skipping it, time.Date resolves midnight to an hour on
+// the day before, so the day instead starts once the clocks have gone
+// forward.
+func startOfDay(date time.Time, loc *time.Location) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	before := func(t time.Time) bool {
+		y, m, d := t.In(loc).Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Before(day)
+	}
+
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	if !before(start) {
+		return start
+	}
+
+	// Clocks go forward on a whole second, within a day of midnight.
+	lo, hi := start, start.Add(24*time.Hour)
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+		if before(mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return hi
+}
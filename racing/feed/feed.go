@@ -0,0 +1,293 @@
+// Package feed imports races from the schedule files of upstream providers.
+package feed
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// columns are the fields a race may be given in, mapped onto whether each
+// is required.
+var columns = map[string]bool{
+	"id":                    true,
+	"meeting_id":            true,
+	"name":                  true,
+	"number":                true,
+	"visible":               false,
+	"advertised_start_time": true,
+	"category":              false,
+}
+
+// row is a single decoded row of a file.
+type row struct {
+	// line is the line of the file the row starts on.
+	line int64
+	race *racing.Race
+	err  error
+}
+
+// Import decodes races from a file in the given format and imports them into
+// repo, reporting each invalid row against the line it starts on. The races
+// changed are recorded in their history, attributed to audit.
+func Import(repo db.RacesRepo, r io.Reader, format racing.ImportRacesRequest_Format, dryRun bool, audit db.Audit) (*racing.ImportRacesResponse, error) {
+	var (
+		rows []row
+		err  error
+	)
+
+	switch format {
+	case racing.ImportRacesRequest_CSV:
+		rows, err = decodeCSV(r)
+	case racing.ImportRacesRequest_NDJSON:
+		rows, err = decodeNDJSON(r)
+	default:
+		return nil, &db.FieldError{Field: "format", Description: fmt.Sprintf("unknown format %q", format)}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		resp  = &racing.ImportRacesResponse{}
+		races []*racing.Race
+		lines []int64
+	)
+
+	for _, row := range rows {
+		if row.err != nil {
+			resp.Errors = append(resp.Errors, &racing.ImportRacesError{Line: row.line, Message: row.err.Error()})
+			continue
+		}
+
+		races = append(races, row.race)
+		lines = append(lines, row.line)
+	}
+
+	// Races are still validated against the database when some rows could
+	// not be decoded, so that every invalid row is reported at once.
+	result, err := repo.Import(races, dryRun || len(resp.Errors) > 0, audit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, race := range races {
+		if err, ok := result.Errors[i]; ok {
+			resp.Errors = append(resp.Errors, &racing.ImportRacesError{Line: lines[i], Message: err.Error()})
+			continue
+		}
+
+		resp.RaceIds = append(resp.RaceIds, race.Id)
+	}
+
+	sort.SliceStable(resp.Errors, func(i, j int) bool { return resp.Errors[i].Line < resp.Errors[j].Line })
+
+	resp.Created = result.Created
+	resp.Updated = result.Updated
+	resp.Unchanged = result.Unchanged
+	resp.Committed = result.Committed
+
+	return resp, nil
+}
+
+// decodeCSV decodes a CSV file, whose first row is a header naming the
+// columns of the rest.
+func decodeCSV(r io.Reader) ([]row, error) {
+	lr := &lineReader{r: bufio.NewReader(r)}
+
+	var (
+		header []string
+		rows   []row
+	)
+
+	for {
+		text, line, err := lr.next(true)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		fields, err := csv.NewReader(strings.NewReader(text)).Read()
+		if err != nil {
+			rows = append(rows, row{line: line, err: fmt.Errorf("%w: %s", db.ErrInvalidArgument, err)})
+			continue
+		}
+
+		if header == nil {
+			header = fields
+			if err := checkHeader(header); err != nil {
+				// Without a usable header no row can be read, but the file
+				// is still read to its end, as its reader may be a stream.
+				if _, err := io.Copy(io.Discard, lr.r); err != nil {
+					return nil, err
+				}
+				return []row{{line: line, err: err}}, nil
+			}
+			continue
+		}
+
+		race, err := parseFields(header, fields)
+		rows = append(rows, row{line: line, race: race, err: err})
+	}
+
+	return rows, nil
+}
+
+// checkHeader checks that a CSV header names every required column, and only
+// known columns.
+func checkHeader(header []string) error {
+	seen := make(map[string]bool)
+
+	for _, name := range header {
+		if _, ok := columns[name]; !ok {
+			return fmt.Errorf("%w: unknown column %q", db.ErrInvalidArgument, name)
+		}
+
+		if seen[name] {
+			return fmt.Errorf("%w: column %q appears more than once", db.ErrInvalidArgument, name)
+		}
+		seen[name] = true
+	}
+
+	for name, required := range columns {
+		if required && !seen[name] {
+			return fmt.Errorf("%w: missing column %q", db.ErrInvalidArgument, name)
+		}
+	}
+
+	return nil
+}
+
+// parseFields parses the fields of a CSV row into a race.
+func parseFields(header, fields []string) (*racing.Race, error) {
+	if len(fields) != len(header) {
+		return nil, fmt.Errorf("%w: row has %d fields, header has %d", db.ErrInvalidArgument, len(fields), len(header))
+	}
+
+	race := &racing.Race{}
+
+	for i, name := range header {
+		value := strings.TrimSpace(fields[i])
+
+		var err error
+		switch name {
+		case "id":
+			race.Id, err = strconv.ParseInt(value, 10, 64)
+		case "meeting_id":
+			race.MeetingId, err = strconv.ParseInt(value, 10, 64)
+		case "name":
+			race.Name = value
+		case "number":
+			race.Number, err = strconv.ParseInt(value, 10, 64)
+		case "visible":
+			if value != "" {
+				race.Visible, err = strconv.ParseBool(value)
+			}
+		case "advertised_start_time":
+			var t time.Time
+			if t, err = time.Parse(time.RFC3339, value); err == nil {
+				race.AdvertisedStartTime, err = ptypes.TimestampProto(t)
+			}
+		case "category":
+			if value != "" {
+				category, ok := racing.RaceCategory_value[strings.ToUpper(value)]
+				if !ok {
+					return nil, fmt.Errorf("%w: category: unknown category %q", db.ErrInvalidArgument, value)
+				}
+				race.Category = racing.RaceCategory(category)
+			}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: cannot parse %q", db.ErrInvalidArgument, name, value)
+		}
+	}
+
+	return race, nil
+}
+
+// decodeNDJSON decodes an NDJSON file, having a JSON race object per line.
+func decodeNDJSON(r io.Reader) ([]row, error) {
+	lr := &lineReader{r: bufio.NewReader(r)}
+
+	var rows []row
+	for {
+		text, line, err := lr.next(false)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		race := &racing.Race{}
+		if err := protojson.Unmarshal([]byte(text), race); err != nil {
+			rows = append(rows, row{line: line, err: fmt.Errorf("%w: %s", db.ErrInvalidArgument, err)})
+			continue
+		}
+
+		// Status and etag are maintained by the service, not imported.
+		race.Status = racing.RaceStatus_STATUS_UNSPECIFIED
+		race.Etag = ""
+
+		rows = append(rows, row{line: line, race: race})
+	}
+
+	return rows, nil
+}
+
+// lineReader reads a file a record at a time, skipping blank lines and
+// tracking the line each record starts on.
+type lineReader struct {
+	r    *bufio.Reader
+	line int64
+}
+
+// next returns the next record and the line it starts on, or io.EOF. When
+// quoted is set, a record continues over as many lines as it takes for its
+// double quotes to balance, as a quoted CSV field may contain line breaks.
+func (l *lineReader) next(quoted bool) (string, int64, error) {
+	var (
+		record string
+		start  int64
+	)
+
+	for {
+		text, err := l.r.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", 0, err
+		}
+		if text == "" && err == io.EOF {
+			if record != "" {
+				return record, start, nil
+			}
+			return "", 0, io.EOF
+		}
+		l.line++
+
+		if record == "" {
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+			start = l.line
+		}
+		record += text
+
+		if !quoted || strings.Count(record, `"`)%2 == 0 || err == io.EOF {
+			return strings.TrimRight(record, "\r\n"), start, nil
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package feed_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/db/dbtest"
+	"git.neds.sh/matty/entain/racing/feed"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+var audit = db.Audit{Actor: "feed", Reason: "testing"}
+
+// newRepo creates an in-memory races repository holding the conformance
+// suite's fixture.
+func newRepo() db.RacesRepo {
+	return db.NewMemoryRacesRepo(db.ClockFunc(func() time.Time { return dbtest.Now }), dbtest.RacesFixture())
+}
+
+// lineError is an error expected on a line, whose message contains text.
+type lineError struct {
+	line int64
+	text string
+}
+
+func TestImportReportsLines(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		format racing.ImportRacesRequest_Format
+		file   string
+		want   []lineError
+	}{
+		{
+			name:   "csv unparseable field",
+			format: racing.ImportRacesRequest_CSV,
+			file: "id,meeting_id,name,number,advertised_start_time\n" +
+				"7,1,Maiden Plate,1,2021-03-02T13:00:00Z\n" +
+				"8,1,Maiden Dash,two,2021-03-02T14:00:00Z\n",
+			want: []lineError{{3, `number: cannot parse "two"`}},
+		},
+		{
+			name:   "csv field count and blank lines",
+			format: racing.ImportRacesRequest_CSV,
+			file: "id,meeting_id,name,number,advertised_start_time\n" +
+				"\n" +
+				"7,1,Maiden Plate,1\n" +
+				"\r\n" +
+				"8,1,Maiden Dash,2,2021-03-02T14:00:00Z,extra\n",
+			want: []lineError{{3, "row has 4 fields, header has 5"}, {5, "row has 6 fields, header has 5"}},
+		},
+		{
+			// A quoted field spanning lines is one row, starting on the
+			// line its quote opens on.
+			name:   "csv quoted line breaks",
+			format: racing.ImportRacesRequest_CSV,
+			file: "id,meeting_id,name,number,advertised_start_time\n" +
+				"7,1,\"Maiden\n" +
+				"Plate\",1,2021-03-02T13:00:00Z\n" +
+				"8,1,Maiden Dash,2,yesterday\n",
+			want: []lineError{{4, `advertised_start_time: cannot parse "yesterday"`}},
+		},
+		{
+			name:   "csv unknown category",
+			format: racing.ImportRacesRequest_CSV,
+			file: "id,meeting_id,name,number,advertised_start_time,category\n" +
+				"7,1,Maiden Plate,1,2021-03-02T13:00:00Z,camel\n",
+			want: []lineError{{2, `unknown category "camel"`}},
+		},
+		{
+			name:   "csv unknown column",
+			format: racing.ImportRacesRequest_CSV,
+			file: "\n" +
+				"id,meeting_id,name,number,advertised_start_time,jockey\n" +
+				"7,1,Maiden Plate,1,2021-03-02T13:00:00Z,Smith\n",
+			want: []lineError{{2, `unknown column "jockey"`}},
+		},
+		{
+			name:   "csv missing column",
+			format: racing.ImportRacesRequest_CSV,
+			file:   "id,meeting_id,name,number\n",
+			want:   []lineError{{1, `missing column "advertised_start_time"`}},
+		},
+		{
+			// Rows that decode are still validated against the database,
+			// so every invalid row is reported together.
+			name:   "csv invalid race",
+			format: racing.ImportRacesRequest_CSV,
+			file: "id,meeting_id,name,number,advertised_start_time\n" +
+				"7,99,Maiden Plate,1,2021-03-02T13:00:00Z\n" +
+				"8,1,Maiden Dash,x,2021-03-02T14:00:00Z\n",
+			want: []lineError{{2, "meeting 99 does not exist"}, {3, `number: cannot parse "x"`}},
+		},
+		{
+			name:   "ndjson malformed and invalid",
+			format: racing.ImportRacesRequest_NDJSON,
+			file: `{"id": "7", "meetingId": "1", "name": "Maiden Plate", "number": "1", "advertisedStartTime": "2021-03-02T13:00:00Z"}` + "\n" +
+				"\n" +
+				`{"id": "8", "meetingId": "1", "name": "Maiden Dash",` + "\n" +
+				`{"id": "9", "meetingId": "99", "name": "Nowhere", "number": "1", "advertisedStartTime": "2021-03-02T13:00:00Z"}` + "\n",
+			want: []lineError{{3, "invalid argument"}, {4, "meeting 99 does not exist"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := newRepo()
+
+			resp, err := feed.Import(repo, strings.NewReader(tc.file), tc.format, false, audit)
+			if err != nil {
+				t.Fatalf("Import: %v", err)
+			}
+
+			if resp.Committed {
+				t.Error("import committed, want nothing written")
+			}
+			if len(resp.Errors) != len(tc.want) {
+				t.Fatalf("errors = %v, want %d", resp.Errors, len(tc.want))
+			}
+			for i, want := range tc.want {
+				got := resp.Errors[i]
+				if got.Line != want.line || !strings.Contains(got.Message, want.text) {
+					t.Errorf("error %d = line %d %q, want line %d containing %q", i, got.Line, got.Message, want.line, want.text)
+				}
+			}
+
+			// Nothing is written when any row is invalid.
+			if _, err := repo.GetRaceById(7, nil, nil); !errors.Is(err, db.ErrNotFound) {
+				t.Errorf("GetRaceById of an unimported race: err = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+// upsert holds a race of the fixture renamed, one unchanged, and a new race.
+const upsert = "id,meeting_id,name,number,visible,advertised_start_time,category\n" +
+	"1,1,Flemington Gold Cup,1,true,2021-03-02T13:00:00Z,thoroughbred\n" +
+	"2,1,Melbourne Stakes,2,true,2021-03-02T11:00:00Z,THOROUGHBRED\n" +
+	"7,2,Maiden Plate,3,false,2021-03-02T16:00:00Z,\n"
+
+func TestImportUpserts(t *testing.T) {
+	repo := newRepo()
+
+	resp, err := feed.Import(repo, strings.NewReader(upsert), racing.ImportRacesRequest_CSV, false, audit)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if !resp.Committed || resp.Created != 1 || resp.Updated != 1 || resp.Unchanged != 1 || len(resp.Errors) != 0 {
+		t.Fatalf("response = %v, want 1 race each created, updated and unchanged, committed", resp)
+	}
+
+	race, err := repo.GetRaceById(1, nil, nil)
+	if err != nil {
+		t.Fatalf("GetRaceById: %v", err)
+	}
+	if race.Name != "Flemington Gold Cup" || race.Etag != "2" {
+		t.Errorf("race = %v, want it renamed at etag 2", race)
+	}
+
+	// A race created without a category takes its meeting's.
+	race, err = repo.GetRaceById(7, nil, nil)
+	if err != nil {
+		t.Fatalf("GetRaceById: %v", err)
+	}
+	if race.Name != "Maiden Plate" || race.Category != racing.RaceCategory_GREYHOUND {
+		t.Errorf("race = %v, want it created as a greyhound race", race)
+	}
+}
+
+func TestImportDryRun(t *testing.T) {
+	repo := newRepo()
+
+	before, _, err := repo.List(&racing.ListRacesRequest{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	resp, err := feed.Import(repo, strings.NewReader(upsert), racing.ImportRacesRequest_CSV, true, audit)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	// What would have been written is reported, but nothing is.
+	if resp.Committed || resp.Created != 1 || resp.Updated != 1 || resp.Unchanged != 1 {
+		t.Fatalf("response = %v, want 1 race each created, updated and unchanged, uncommitted", resp)
+	}
+
+	after, _, err := repo.List(&racing.ListRacesRequest{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(after) != len(before) {
+		t.Fatalf("races = %v, want %v", after, before)
+	}
+	for i := range after {
+		if after[i].Name != before[i].Name || after[i].Etag != before[i].Etag {
+			t.Errorf("race = %v, want %v", after[i], before[i])
+		}
+	}
+
+	changes, _, err := repo.History(&racing.ListRaceHistoryRequest{RaceId: 1})
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("changes = %v, want none recorded", changes)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/feed"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// runImport loads races from a CSV or NDJSON file into the racing database,
+// reporting each row that could not be imported.
+func runImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	format := flags.String("format", "", "file format, csv or ndjson (default from the file extension)")
+	dryRun := flags.Bool("dry-run", false, "validate the file without writing any races")
+	actor := flags.String("actor", currentUser(), "who the changes are recorded in race history as made by")
+	reason := flags.String("reason", "", "why the changes are made, as recorded in race history (default imported from FILE)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: racing import [-format csv|ndjson] [-dry-run] [-actor NAME] [-reason TEXT] FILE")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("expected a single file")
+	}
+	path := flags.Arg(0)
+
+	if *reason == "" {
+		*reason = "imported from " + filepath.Base(path)
+	}
+
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+
+	value, ok := racing.ImportRacesRequest_Format_value[strings.ToUpper(*format)]
+	if !ok || value == int32(racing.ImportRacesRequest_FORMAT_UNSPECIFIED) {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	racingDB, err := openDB()
+	if err != nil {
+		return err
+	}
+
+	racesRepo := db.NewRacesRepo(racingDB, db.SystemClock)
+	if err := racesRepo.Init(); err != nil {
+		return err
+	}
+
+	// Races are validated against the meetings they belong to.
+	meetingsRepo := db.NewMeetingsRepo(racingDB)
+	if err := meetingsRepo.Init(); err != nil {
+		return err
+	}
+
+	resp, err := feed.Import(racesRepo, f, racing.ImportRacesRequest_Format(value), *dryRun, db.Audit{Actor: *actor, Reason: *reason})
+	if err != nil {
+		return err
+	}
+
+	for _, rowErr := range resp.Errors {
+		fmt.Fprintf(os.Stderr, "%s:%d: %s\n", path, rowErr.Line, rowErr.Message)
+	}
+
+	fmt.Printf("%d created, %d updated, %d unchanged\n", resp.Created, resp.Updated, resp.Unchanged)
+
+	switch {
+	case len(resp.Errors) > 0:
+		return fmt.Errorf("%d rows could not be imported, so no races were written", len(resp.Errors))
+	case !resp.Committed:
+		fmt.Println("dry run, so no races were written")
+	}
+
+	return nil
+}
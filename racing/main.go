@@ -2,25 +2,64 @@ package main
 
 import (
 	"database/sql"
+	"errors"
 	"flag"
 	"log"
 	"net"
+	"os/user"
+	// Venue time zones are loaded from the database embedded in the binary,
+	// so they do not depend on that of the host.
+	_ "time/tzdata"
 
 	"git.neds.sh/matty/entain/racing/db"
 	"git.neds.sh/matty/entain/racing/proto/racing"
 	"git.neds.sh/matty/entain/racing/service"
+	"git.neds.sh/matty/entain/shared/retention"
 	"google.golang.org/grpc"
 )
 
 var (
 	grpcEndpoint = flag.String("grpc-endpoint", "localhost:9000", "gRPC server endpoint")
+	mode         = flag.String("mode", developmentMode, "mode to run in, development or production")
 )
 
+// The modes the service may be run in. Dummy data is only seeded in
+// development mode, unless forced.
+const (
+	developmentMode = "development"
+	productionMode  = "production"
+)
+
+// racingDBPath is the path of the racing database.
+const racingDBPath = "./db/racing.db"
+
 func main() {
 	flag.Parse()
 
-	if err := run(); err != nil {
-		log.Fatalf("failed running grpc server: %s\n", err)
+	if *mode != developmentMode && *mode != productionMode {
+		log.Fatalf("unknown mode %q\n", *mode)
+	}
+
+	// With no command, the gRPC server is run.
+	switch flag.Arg(0) {
+	case "":
+		if err := run(); err != nil {
+			log.Fatalf("failed running grpc server: %s\n", err)
+		}
+	case "import":
+		if err := runImport(flag.Args()[1:]); err != nil {
+			log.Fatalf("failed importing races: %s\n", err)
+		}
+	case "migrate":
+		if err := runMigrate(flag.Args()[1:]); err != nil {
+			log.Fatalf("failed migrating racing database: %s\n", err)
+		}
+	case "seed":
+		if err := runSeed(flag.Args()[1:]); err != nil {
+			log.Fatalf("failed seeding racing database: %s\n", err)
+		}
+	default:
+		log.Fatalf("unknown command %q\n", flag.Arg(0))
 	}
 }
 
@@ -30,25 +69,58 @@ func run() error {
 		return err
 	}
 
-	racingDB, err := sql.Open("sqlite3", "./db/racing.db")
+	racingDB, err := openDB()
 	if err != nil {
 		return err
 	}
 
-	racesRepo := db.NewRacesRepo(racingDB)
+	racesRepo := db.NewRacesRepo(racingDB, db.SystemClock)
 	if err := racesRepo.Init(); err != nil {
 		return err
 	}
 
-	grpcServer := grpc.NewServer()
+	meetingsRepo := db.NewMeetingsRepo(racingDB)
+	if err := meetingsRepo.Init(); err != nil {
+		return err
+	}
+
+	runnersRepo := db.NewRunnersRepo(racingDB)
+	if err := runnersRepo.Init(); err != nil {
+		return err
+	}
+
+	resultsRepo := db.NewResultsRepo(racingDB)
+	if err := resultsRepo.Init(); err != nil {
+		return err
+	}
 
-	racing.RegisterRacingServer(
-		grpcServer,
-		service.NewRacingService(
-			racesRepo,
-		),
+	pricesRepo := db.NewPricesRepo(racingDB)
+	if err := pricesRepo.Init(); err != nil {
+		return err
+	}
+
+	racingService := service.NewRacingService(
+		racesRepo,
+		meetingsRepo,
+		runnersRepo,
+		resultsRepo,
+		pricesRepo,
+		db.SystemClock,
 	)
 
+	switch {
+	case *retentionAge < 0:
+		return errors.New("-retention-age must not be negative")
+	case *retentionAge > 0 && *retentionInterval <= 0:
+		return errors.New("-retention-interval must be positive")
+	case *retentionAge > 0:
+		go retention.Run(archiveRaces(racingService.(service.Archiver), *retentionAge), *retentionInterval, "races finished more than "+retentionAge.String()+" ago")
+	}
+
+	grpcServer := grpc.NewServer()
+
+	racing.RegisterRacingServer(grpcServer, racingService)
+
 	log.Printf("gRPC server listening on: %s\n", *grpcEndpoint)
 
 	if err := grpcServer.Serve(conn); err != nil {
@@ -57,3 +129,34 @@ func run() error {
 
 	return nil
 }
+
+// openDB opens the racing database, migrating its schema up to date before
+// any repository uses it.
+func openDB() (*sql.DB, error) {
+	racingDB, err := sql.Open("sqlite3", racingDBPath)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := db.Migrate(racingDB)
+	for _, m := range applied {
+		log.Printf("applied migration %d %s\n", m.Version, m.Name)
+	}
+	if err != nil {
+		racingDB.Close()
+		return nil, err
+	}
+
+	return racingDB, nil
+}
+
+// currentUser names the user running a command, who the changes it makes are
+// recorded as made by unless it is told otherwise.
+func currentUser() string {
+	u, err := user.Current()
+	if err != nil || u.Username == "" {
+		return "anonymous"
+	}
+
+	return u.Username
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"git.neds.sh/matty/entain/racing/db"
+)
+
+// runMigrate runs a migration command against the racing database: up, to
+// apply every pending migration, or status, to list each migration and
+// whether it has been applied.
+func runMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: racing migrate up|status")
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("expected a single command")
+	}
+
+	racingDB, err := sql.Open("sqlite3", racingDBPath)
+	if err != nil {
+		return err
+	}
+	defer racingDB.Close()
+
+	switch flags.Arg(0) {
+	case "up":
+		applied, err := db.Migrate(racingDB)
+		for _, m := range applied {
+			fmt.Printf("applied %d %s\n", m.Version, m.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+		return err
+	case "status":
+		statuses, err := db.SchemaStatus(racingDB)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+		for _, m := range statuses {
+			applied := "pending"
+			switch {
+			case !m.Known:
+				applied = m.Applied.Format(time.RFC3339) + " (unknown to this build)"
+			case !m.Applied.IsZero():
+				applied = m.Applied.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", m.Version, m.Name, applied)
+		}
+		return w.Flush()
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown migrate command %q", flags.Arg(0))
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"flag"
+	"time"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/service"
+	"git.neds.sh/matty/entain/shared/retention"
+)
+
+var (
+	retentionAge      = flag.Duration("retention-age", 90*24*time.Hour, "how long after its start a finished race is archived, or 0 to never archive races")
+	retentionInterval = flag.Duration("retention-interval", time.Hour, "how often finished races are checked for archiving")
+)
+
+// archiveRaces archives races that finished more than age ago through the
+// service, so that their watchers see them archived.
+func archiveRaces(archiver service.Archiver, age time.Duration) retention.Archiver {
+	audit := db.Audit{Actor: "retention", Reason: "finished more than " + age.String() + " ago"}
+
+	return func(limit int) (int, error) {
+		return archiver.ArchiveRaces(age, limit, audit)
+	}
+}
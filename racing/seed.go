@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"git.neds.sh/matty/entain/racing/db"
+)
+
+// runSeed fills the racing database with generated dummy meetings, races and
+// runners. The same flags always generate the same data, so that
+// environments seeded alike hold identical datasets.
+func runSeed(args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	seed := flags.Int64("seed", 1, "seed of the random data generated")
+	meetings := flags.Int("meetings", 10, "number of meetings, held at each venue in turn")
+	racesPerMeeting := flags.Int("races-per-meeting", 10, "number of races at each meeting")
+	minRunners := flags.Int("min-runners", 8, "fewest runners in a race")
+	maxRunners := flags.Int("max-runners", 12, "most runners in a race")
+	from := flags.String("from", "", "first day meetings are held on, as YYYY-MM-DD (default yesterday, UTC)")
+	days := flags.Int("days", 3, "number of days meetings are spread across")
+	batchSize := flags.Int("batch-size", 500, "most rows inserted per transaction")
+	reset := flags.Bool("reset", false, "delete all existing races, meetings and runners, along with their results and prices, first")
+	force := flags.Bool("force", false, "seed even in production mode")
+	actor := flags.String("actor", currentUser(), "who the races are recorded in race history as created and deleted by")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: racing seed [flags]")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return errors.New("expected no arguments")
+	}
+
+	if *mode == productionMode && !*force {
+		return errors.New("seeding is disabled in production mode; pass -force to seed anyway")
+	}
+
+	// Without a first day, the dataset is only the same for runs on the
+	// same day.
+	first := time.Now().UTC().AddDate(0, 0, -1)
+	if *from != "" {
+		var err error
+		if first, err = time.Parse("2006-01-02", *from); err != nil {
+			return fmt.Errorf("invalid -from: %w", err)
+		}
+	}
+
+	racingDB, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer racingDB.Close()
+
+	result, err := db.Seed(racingDB, db.SeedOptions{
+		Seed:            *seed,
+		Meetings:        *meetings,
+		RacesPerMeeting: *racesPerMeeting,
+		MinRunners:      *minRunners,
+		MaxRunners:      *maxRunners,
+		From:            first,
+		Days:            *days,
+		BatchSize:       *batchSize,
+		Reset:           *reset,
+		Audit:           db.Audit{Actor: *actor, Reason: fmt.Sprintf("seeded with seed %d from %s", *seed, first.Format("2006-01-02"))},
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("seeded %d meetings, %d races and %d runners from %s with seed %d\n",
+		result.Meetings, result.Races, result.Runners, first.Format("2006-01-02"), *seed)
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"strings"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// actorKey and reasonKey are the metadata keys callers name themselves
+	// and give their reason for a change under.
+	actorKey  = "x-actor"
+	reasonKey = "x-reason"
+
+	// anonymousActor is who changes are attributed to when the caller names
+	// no one.
+	anonymousActor = "anonymous"
+)
+
+// auditFrom returns who is making a call and why, as the caller gave them in
+// its metadata, for the history of the races the call changes.
+func auditFrom(ctx context.Context) db.Audit {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	audit := db.Audit{
+		Actor:  strings.TrimSpace(strings.Join(md.Get(actorKey), ", ")),
+		Reason: strings.TrimSpace(strings.Join(md.Get(reasonKey), ", ")),
+	}
+	if audit.Actor == "" {
+		audit.Actor = anonymousActor
+	}
+
+	return audit
+}
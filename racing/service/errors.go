@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatusError converts an error returned by a repository into a gRPC
+// status error, with a code matching the kind of failure. Errors of no known
+// kind, such as those from the database, are logged and reported as Internal
+// without their message, which would expose the service's internals.
+func toStatusError(err error) error {
+	// Errors that are already statuses, such as those from receiving on a
+	// stream, are passed through.
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var fieldErr *db.FieldError
+	switch {
+	case errors.As(err, &fieldErr):
+		return badRequest(err, fieldErr)
+	case errors.Is(err, db.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, db.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, db.ErrFailedPrecondition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, db.ErrAborted):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	log.Printf("internal error: %v", err)
+
+	return status.Error(codes.Internal, "internal error")
+}
+
+// badRequest builds an InvalidArgument status carrying the field at fault as
+// a BadRequest field violation, which clients can attribute to their input.
+func badRequest(err error, fieldErr *db.FieldError) error {
+	st := status.New(codes.InvalidArgument, err.Error())
+
+	detailed, detailErr := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: fieldErr.Field, Description: fieldErr.Description},
+		},
+	})
+	if detailErr != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
@@ -1,9 +1,20 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
 	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/feed"
 	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/shared/readmask"
+	"github.com/golang/protobuf/ptypes/empty"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type Racing interface {
@@ -12,32 +23,405 @@ type Racing interface {
 
 	// GetRaceById will return a single race.
 	GetRaceById(ctx context.Context, in *racing.GetRaceByIdRequest) (*racing.GetRaceByIdResponse, error)
+
+	// BatchGetRaces will return the races with the given IDs.
+	BatchGetRaces(ctx context.Context, in *racing.BatchGetRacesRequest) (*racing.BatchGetRacesResponse, error)
+
+	// TransitionRace will move a race on to a new status.
+	TransitionRace(ctx context.Context, in *racing.TransitionRaceRequest) (*racing.TransitionRaceResponse, error)
+
+	// ListMeetings will return a collection of meetings.
+	ListMeetings(ctx context.Context, in *racing.ListMeetingsRequest) (*racing.ListMeetingsResponse, error)
+
+	// GetMeeting will return a single meeting, optionally with its races.
+	GetMeeting(ctx context.Context, in *racing.GetMeetingRequest) (*racing.GetMeetingResponse, error)
+
+	// ListRunners will return a collection of runners.
+	ListRunners(ctx context.Context, in *racing.ListRunnersRequest) (*racing.ListRunnersResponse, error)
+
+	// GetRunner will return a single runner.
+	GetRunner(ctx context.Context, in *racing.GetRunnerRequest) (*racing.GetRunnerResponse, error)
+
+	// ScratchRunner will withdraw a runner from its race.
+	ScratchRunner(ctx context.Context, in *racing.ScratchRunnerRequest) (*racing.ScratchRunnerResponse, error)
+
+	// RecordResult will record the result of a race.
+	RecordResult(ctx context.Context, in *racing.RecordResultRequest) (*racing.RecordResultResponse, error)
+
+	// GetResult will return the result of a race.
+	GetResult(ctx context.Context, in *racing.GetResultRequest) (*racing.GetResultResponse, error)
+
+	// UpdatePrices will apply a new version of prices to a race's market.
+	UpdatePrices(ctx context.Context, in *racing.UpdatePricesRequest) (*racing.UpdatePricesResponse, error)
+
+	// GetPrices will return the market of a race.
+	GetPrices(ctx context.Context, in *racing.GetPricesRequest) (*racing.GetPricesResponse, error)
+
+	// WatchRaces will stream changes to the races matching a filter.
+	WatchRaces(in *racing.WatchRacesRequest, stream racing.Racing_WatchRacesServer) error
+
+	// CreateRace will create a new race.
+	CreateRace(ctx context.Context, in *racing.CreateRaceRequest) (*racing.Race, error)
+
+	// UpdateRace will update the fields of a race named by an update mask.
+	UpdateRace(ctx context.Context, in *racing.UpdateRaceRequest) (*racing.Race, error)
+
+	// DeleteRace will delete a race.
+	DeleteRace(ctx context.Context, in *racing.DeleteRaceRequest) (*empty.Empty, error)
+
+	// ListNextToJump will return the open races starting soonest.
+	ListNextToJump(ctx context.Context, in *racing.ListNextToJumpRequest) (*racing.ListNextToJumpResponse, error)
+
+	// ImportRaces will load races from a file streamed in chunks.
+	ImportRaces(stream racing.Racing_ImportRacesServer) error
+
+	// SearchRaces will return the races whose names match a query.
+	SearchRaces(ctx context.Context, in *racing.SearchRacesRequest) (*racing.SearchRacesResponse, error)
+
+	// ListRaceHistory will return the changes made to a race.
+	ListRaceHistory(ctx context.Context, in *racing.ListRaceHistoryRequest) (*racing.ListRaceHistoryResponse, error)
 }
 
-// racingService implements the Racing interface.
+// Archiver is implemented by the racing service alongside Racing, for the
+// retention the service runs in the background rather than serves.
+type Archiver interface {
+	// ArchiveRaces will archive up to limit races that finished more than
+	// olderThan ago, returning how many it archived.
+	ArchiveRaces(olderThan time.Duration, limit int, audit db.Audit) (int, error)
+}
+
+var _ Archiver = (*racingService)(nil)
+
+// racingService implements the Racing and Archiver interfaces.
 type racingService struct {
-	racesRepo db.RacesRepo
+	racesRepo    db.RacesRepo
+	meetingsRepo db.MeetingsRepo
+	runnersRepo  db.RunnersRepo
+	resultsRepo  db.ResultsRepo
+	pricesRepo   db.PricesRepo
+	clock        db.Clock
+	watchers     *raceWatchers
 }
 
-// NewRacingService instantiates and returns a new racingService.
-func NewRacingService(racesRepo db.RacesRepo) Racing {
-	return &racingService{racesRepo}
+// NewRacingService instantiates and returns a new racingService, telling the
+// time of the writes it makes by clock. It is also an Archiver.
+func NewRacingService(racesRepo db.RacesRepo, meetingsRepo db.MeetingsRepo, runnersRepo db.RunnersRepo, resultsRepo db.ResultsRepo, pricesRepo db.PricesRepo, clock db.Clock) Racing {
+	s := &racingService{
+		racesRepo:    racesRepo,
+		meetingsRepo: meetingsRepo,
+		runnersRepo:  runnersRepo,
+		resultsRepo:  resultsRepo,
+		pricesRepo:   pricesRepo,
+		clock:        clock,
+	}
+	s.watchers = newRaceWatchers(clock, s.nextRaceCloses)
+
+	return s
 }
 
 func (s *racingService) ListRaces(ctx context.Context, in *racing.ListRacesRequest) (*racing.ListRacesResponse, error) {
-	races, err := s.racesRepo.List(in.Filter)
+	races, nextPageToken, err := s.racesRepo.List(in)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
-	return &racing.ListRacesResponse{Races: races}, nil
+	// Fields only read to page on are not returned.
+	for _, race := range races {
+		readmask.Apply(race, in.ReadMask)
+	}
+
+	return &racing.ListRacesResponse{Races: races, NextPageToken: nextPageToken}, nil
 }
 
 func (s *racingService) GetRaceById(ctx context.Context, req *racing.GetRaceByIdRequest) (*racing.GetRaceByIdResponse, error) {
-	race, err := s.racesRepo.GetRaceById(req.RaceId)
+	race, err := s.racesRepo.GetRaceById(req.RaceId, req.AsOf, req.ReadMask)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// Fields only read to derive others are not returned.
+	readmask.Apply(race, req.ReadMask)
+
+	resp := &racing.GetRaceByIdResponse{Race: race}
+	if req.IncludeRunners {
+		resp.Runners, err = s.runnersRepo.ListByRace(req.RaceId)
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+	}
+
+	// A race that has not been priced yet is returned without a market.
+	if req.IncludePrices {
+		resp.Market, _, err = s.pricesRepo.Get(req.RaceId, false)
+		if err != nil && !errors.Is(err, db.ErrNotFound) {
+			return nil, toStatusError(err)
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *racingService) BatchGetRaces(ctx context.Context, in *racing.BatchGetRacesRequest) (*racing.BatchGetRacesResponse, error) {
+	races, missing, err := s.racesRepo.BatchGet(in)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &racing.BatchGetRacesResponse{Races: races, MissingRaceIds: missing}, nil
+}
+
+func (s *racingService) TransitionRace(ctx context.Context, in *racing.TransitionRaceRequest) (*racing.TransitionRaceResponse, error) {
+	race, err := s.racesRepo.TransitionRace(in.RaceId, in.Status, in.Etag, auditFrom(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	s.watchers.publish(race.Id)
+
+	return &racing.TransitionRaceResponse{Race: race}, nil
+}
+
+func (s *racingService) CreateRace(ctx context.Context, in *racing.CreateRaceRequest) (*racing.Race, error) {
+	race, err := s.racesRepo.Create(in.Race, auditFrom(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	s.watchers.publish(race.Id)
+
+	return race, nil
+}
+
+func (s *racingService) UpdateRace(ctx context.Context, in *racing.UpdateRaceRequest) (*racing.Race, error) {
+	race, err := s.racesRepo.Update(in.Race, in.GetUpdateMask().GetPaths(), auditFrom(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	s.watchers.publish(race.Id)
+
+	return race, nil
+}
+
+func (s *racingService) DeleteRace(ctx context.Context, in *racing.DeleteRaceRequest) (*empty.Empty, error) {
+	if err := s.racesRepo.Delete(in.RaceId, in.Etag, auditFrom(ctx)); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	s.watchers.publish(in.RaceId)
+
+	return &empty.Empty{}, nil
+}
+
+func (s *racingService) ListNextToJump(ctx context.Context, in *racing.ListNextToJumpRequest) (*racing.ListNextToJumpResponse, error) {
+	races, err := s.racesRepo.NextToJump(in)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &racing.ListNextToJumpResponse{Races: races}, nil
+}
+
+func (s *racingService) ImportRaces(stream racing.Racing_ImportRacesServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "no file given")
+	}
+	if err != nil {
+		return err
+	}
+
+	switch first.Format {
+	case racing.ImportRacesRequest_CSV, racing.ImportRacesRequest_NDJSON:
+	default:
+		return toStatusError(&db.FieldError{Field: "format", Description: fmt.Sprintf("unknown format %q", first.Format)})
+	}
+
+	// Chunks are fed to the decoder as they arrive, rather than the file
+	// being buffered whole. The decoder reads to the end of the file before
+	// returning, by which point the stream has been fully received.
+	pr, pw := io.Pipe()
+	received := make(chan struct{})
+	go func() {
+		defer close(received)
+
+		_, err := pw.Write(first.Data)
+		for err == nil {
+			var in *racing.ImportRacesRequest
+			if in, err = stream.Recv(); err == nil {
+				_, err = pw.Write(in.Data)
+			}
+		}
+		if err == io.EOF {
+			err = nil
+		}
+		pw.CloseWithError(err)
+	}()
+	defer func() {
+		pr.Close()
+		<-received
+	}()
+
+	resp, err := feed.Import(s.racesRepo, pr, first.Format, first.DryRun, auditFrom(stream.Context()))
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	if resp.Committed {
+		for _, id := range resp.RaceIds {
+			s.watchers.publish(id)
+		}
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+func (s *racingService) SearchRaces(ctx context.Context, in *racing.SearchRacesRequest) (*racing.SearchRacesResponse, error) {
+	results, nextPageToken, err := s.racesRepo.Search(in)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &racing.SearchRacesResponse{Results: results, NextPageToken: nextPageToken}, nil
+}
+
+func (s *racingService) ListRaceHistory(ctx context.Context, in *racing.ListRaceHistoryRequest) (*racing.ListRaceHistoryResponse, error) {
+	changes, nextPageToken, err := s.racesRepo.History(in)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &racing.ListRaceHistoryResponse{Changes: changes, NextPageToken: nextPageToken}, nil
+}
+
+func (s *racingService) ArchiveRaces(olderThan time.Duration, limit int, audit db.Audit) (int, error) {
+	ids, err := s.racesRepo.Archive(olderThan, limit, audit)
+	if err != nil {
+		return 0, err
+	}
+
+	// Watchers not asking for archived races see them go.
+	for _, id := range ids {
+		s.watchers.publish(id)
+	}
+
+	return len(ids), nil
+}
+
+func (s *racingService) ListMeetings(ctx context.Context, in *racing.ListMeetingsRequest) (*racing.ListMeetingsResponse, error) {
+	meetings, nextPageToken, err := s.meetingsRepo.List(in)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &racing.ListMeetingsResponse{Meetings: meetings, NextPageToken: nextPageToken}, nil
+}
+
+func (s *racingService) GetMeeting(ctx context.Context, in *racing.GetMeetingRequest) (*racing.GetMeetingResponse, error) {
+	meeting, err := s.meetingsRepo.Get(in.MeetingId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &racing.GetMeetingResponse{Meeting: meeting}
+	if !in.IncludeRaces {
+		return resp, nil
+	}
+
+	races, err := s.listAllRaces(&racing.ListRacesRequest{
+		Filter:  &racing.ListRacesRequestFilter{MeetingIds: []int64{meeting.Id}},
+		OrderBy: []*racing.RaceOrder{{Field: racing.RaceOrder_NUMBER}},
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp.Races = races
+
+	return resp, nil
+}
+
+// listAllRaces pages through every race matching a request, as there may in
+// principle be more than fit in a single page.
+func (s *racingService) listAllRaces(req *racing.ListRacesRequest) ([]*racing.Race, error) {
+	req = proto.Clone(req).(*racing.ListRacesRequest)
+
+	var all []*racing.Race
+	for {
+		races, nextPageToken, err := s.racesRepo.List(req)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, races...)
+		if nextPageToken == "" {
+			return all, nil
+		}
+		req.PageToken = nextPageToken
+	}
+}
+
+func (s *racingService) ListRunners(ctx context.Context, in *racing.ListRunnersRequest) (*racing.ListRunnersResponse, error) {
+	runners, nextPageToken, err := s.runnersRepo.List(in)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &racing.ListRunnersResponse{Runners: runners, NextPageToken: nextPageToken}, nil
+}
+
+func (s *racingService) GetRunner(ctx context.Context, in *racing.GetRunnerRequest) (*racing.GetRunnerResponse, error) {
+	runner, err := s.runnersRepo.Get(in.RunnerId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &racing.GetRunnerResponse{Runner: runner}, nil
+}
+
+func (s *racingService) ScratchRunner(ctx context.Context, in *racing.ScratchRunnerRequest) (*racing.ScratchRunnerResponse, error) {
+	runner, err := s.runnersRepo.Scratch(in.RunnerId, in.Reason, s.clock.Now(), auditFrom(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &racing.ScratchRunnerResponse{Runner: runner}, nil
+}
+
+func (s *racingService) RecordResult(ctx context.Context, in *racing.RecordResultRequest) (*racing.RecordResultResponse, error) {
+	result, err := s.resultsRepo.Record(in, s.clock.Now(), auditFrom(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	s.watchers.publish(result.RaceId)
+
+	return &racing.RecordResultResponse{Result: result}, nil
+}
+
+func (s *racingService) GetResult(ctx context.Context, in *racing.GetResultRequest) (*racing.GetResultResponse, error) {
+	result, err := s.resultsRepo.Get(in.RaceId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &racing.GetResultResponse{Result: result}, nil
+}
+
+func (s *racingService) UpdatePrices(ctx context.Context, in *racing.UpdatePricesRequest) (*racing.UpdatePricesResponse, error) {
+	market, err := s.pricesRepo.Update(in, s.clock.Now(), auditFrom(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &racing.UpdatePricesResponse{Market: market}, nil
+}
+
+func (s *racingService) GetPrices(ctx context.Context, in *racing.GetPricesRequest) (*racing.GetPricesResponse, error) {
+	market, history, err := s.pricesRepo.Get(in.RaceId, in.IncludeHistory)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
-	return &racing.GetRaceByIdResponse{Race: race}, nil
+	return &racing.GetPricesResponse{Market: market, History: history}, nil
 }
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	_ "github.com/mattn/go-sqlite3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// newSQLiteService creates a service over a migrated SQLite database, holding
+// race 1 starting at start with runners 1 and 2, whose time is told by clock.
+func newSQLiteService(t *testing.T, start time.Time, clock db.Clock) *racingService {
+	racingDB, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "racing.db"))
+	if err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+	t.Cleanup(func() { racingDB.Close() })
+
+	if _, err := db.Migrate(racingDB); err != nil {
+		if strings.Contains(err.Error(), "no such module: fts5") {
+			t.Fatalf("SQLite is built without FTS5; run the tests with -tags sqlite_fts5, as make test does: %v", err)
+		}
+		t.Fatalf("migrating: %v", err)
+	}
+
+	for _, stmt := range []string{
+		`INSERT INTO meetings (id, name, country, date, category, time_zone) VALUES (1, 'Flemington', 'AUS', '2021-03-02', 'THOROUGHBRED', 'Australia/Melbourne')`,
+		`INSERT INTO races (id, meeting_id, name, number, visible, advertised_start_time, status, category) VALUES (1, 1, 'Flemington Cup', 1, 1, '` + start.Format(time.RFC3339Nano) + `', 'OPEN', 'THOROUGHBRED')`,
+		`INSERT INTO runners (id, race_id, number, name, barrier, weight) VALUES (1, 1, 1, 'Black Caviar', 1, 58.5), (2, 1, 2, 'Winx', 2, 57)`,
+	} {
+		if _, err := racingDB.Exec(stmt); err != nil {
+			t.Fatalf("inserting fixture: %v", err)
+		}
+	}
+
+	return NewRacingService(
+		db.NewRacesRepo(racingDB, clock),
+		db.NewMeetingsRepo(racingDB),
+		db.NewRunnersRepo(racingDB),
+		db.NewResultsRepo(racingDB),
+		db.NewPricesRepo(racingDB),
+		clock,
+	).(*racingService)
+}
+
+func TestRacingServiceClock(t *testing.T) {
+	var (
+		start = time.Date(2021, 3, 2, 13, 0, 0, 0, time.UTC)
+		now   = start.Add(-time.Minute)
+		clock = db.ClockFunc(func() time.Time { return now })
+		s     = newSQLiteService(t, start, clock)
+		ctx   = context.Background()
+	)
+
+	race, err := s.GetRaceById(ctx, &racing.GetRaceByIdRequest{RaceId: 1})
+	if err != nil {
+		t.Fatalf("GetRaceById: %v", err)
+	}
+	if race.Race.Status != racing.RaceStatus_OPEN {
+		t.Errorf("race = %v before its start, want it OPEN", race.Race)
+	}
+
+	scratched, err := s.ScratchRunner(ctx, &racing.ScratchRunnerRequest{RunnerId: 2, Reason: "lame"})
+	if err != nil {
+		t.Fatalf("ScratchRunner: %v", err)
+	}
+	if got := scratched.Runner.ScratchedTime.AsTime(); !got.Equal(now) {
+		t.Errorf("scratched time = %s, want %s", got, now)
+	}
+
+	// Nothing can be recorded until the clock passes the race's start.
+	record := &racing.RecordResultRequest{RaceId: 1, Placings: []*racing.Placing{{RunnerId: 1, Position: 1}}}
+	if _, err := s.RecordResult(ctx, record); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("RecordResult of an open race: err = %v, want FailedPrecondition", err)
+	}
+
+	now = start.Add(time.Minute)
+
+	race, err = s.GetRaceById(ctx, &racing.GetRaceByIdRequest{RaceId: 1})
+	if err != nil {
+		t.Fatalf("GetRaceById: %v", err)
+	}
+	if race.Race.Status != racing.RaceStatus_CLOSED {
+		t.Errorf("race = %v after its start, want it CLOSED", race.Race)
+	}
+
+	result, err := s.RecordResult(ctx, record)
+	if err != nil {
+		t.Fatalf("RecordResult: %v", err)
+	}
+	if got := result.Result.RecordedTime.AsTime(); !got.Equal(now) {
+		t.Errorf("recorded time = %s, want %s", got, now)
+	}
+}
+
+func TestRacingServiceAuditsRunnersAndPrices(t *testing.T) {
+	start := time.Date(2021, 3, 2, 13, 0, 0, 0, time.UTC)
+	s := newSQLiteService(t, start, db.ClockFunc(func() time.Time { return start.Add(-time.Hour) }))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(actorKey, "steward", reasonKey, "vet check"))
+
+	if _, err := s.UpdatePrices(ctx, &racing.UpdatePricesRequest{RaceId: 1, Version: 1, Prices: []*racing.Price{
+		{RunnerId: 1, WinOdds: 2.5, PlaceOdds: 1.2},
+		{RunnerId: 2, WinOdds: 4, PlaceOdds: 1.6},
+	}}); err != nil {
+		t.Fatalf("UpdatePrices: %v", err)
+	}
+	if _, err := s.UpdatePrices(ctx, &racing.UpdatePricesRequest{RaceId: 1, Version: 2, Prices: []*racing.Price{
+		{RunnerId: 1, WinOdds: 2.25, PlaceOdds: 1.2},
+	}}); err != nil {
+		t.Fatalf("UpdatePrices: %v", err)
+	}
+	if _, err := s.ScratchRunner(ctx, &racing.ScratchRunnerRequest{RunnerId: 2, Reason: "lame"}); err != nil {
+		t.Fatalf("ScratchRunner: %v", err)
+	}
+
+	history, err := s.ListRaceHistory(ctx, &racing.ListRaceHistoryRequest{RaceId: 1})
+	if err != nil {
+		t.Fatalf("ListRaceHistory: %v", err)
+	}
+
+	// Each is recorded against the race, leaving its etag as it was.
+	want := [][]*racing.FieldChange{
+		{
+			{Field: "market.version", NewValue: "1"},
+			{Field: "runners.1.win_odds", NewValue: "2.5"},
+			{Field: "runners.1.place_odds", NewValue: "1.2"},
+			{Field: "runners.2.win_odds", NewValue: "4"},
+			{Field: "runners.2.place_odds", NewValue: "1.6"},
+		},
+		{
+			{Field: "market.version", OldValue: "1", NewValue: "2"},
+			{Field: "runners.1.win_odds", OldValue: "2.5", NewValue: "2.25"},
+		},
+		{
+			{Field: "runners.2.scratched", OldValue: "false", NewValue: "true"},
+			{Field: "runners.2.scratching_reason", NewValue: "lame"},
+		},
+	}
+	if len(history.Changes) != len(want) {
+		t.Fatalf("changes = %v, want %d", history.Changes, len(want))
+	}
+	for i, change := range history.Changes {
+		if change.Action != racing.RaceChange_UPDATED || change.Actor != "steward" || change.Reason != "vet check" || change.Etag != "1" {
+			t.Errorf("change %d = %v, want an update by steward for a vet check at etag 1", i, change)
+		}
+		if !reflect.DeepEqual(fieldStrings(change.Fields), fieldStrings(want[i])) {
+			t.Errorf("change %d fields = %v, want %v", i, change.Fields, want[i])
+		}
+	}
+}
+
+func TestRacingServiceGetMeeting(t *testing.T) {
+	start := time.Date(2021, 3, 2, 13, 0, 0, 0, time.UTC)
+	s := newSQLiteService(t, start, db.ClockFunc(func() time.Time { return start.Add(-time.Hour) }))
+	ctx := context.Background()
+
+	later, _ := ptypes.TimestampProto(start.Add(time.Hour))
+	for _, race := range []*racing.Race{
+		{MeetingId: 1, Name: "Maiden Plate", Number: 3, Visible: true, AdvertisedStartTime: later},
+		{MeetingId: 1, Name: "Melbourne Stakes", Number: 2, Visible: true, AdvertisedStartTime: later},
+	} {
+		if _, err := s.CreateRace(ctx, &racing.CreateRaceRequest{Race: race}); err != nil {
+			t.Fatalf("CreateRace: %v", err)
+		}
+	}
+
+	resp, err := s.GetMeeting(ctx, &racing.GetMeetingRequest{MeetingId: 1})
+	if err != nil {
+		t.Fatalf("GetMeeting: %v", err)
+	}
+	if resp.Meeting.Name != "Flemington" || len(resp.Races) != 0 {
+		t.Errorf("response = %v, want Flemington without its races", resp)
+	}
+
+	// Races are included ordered by number.
+	resp, err = s.GetMeeting(ctx, &racing.GetMeetingRequest{MeetingId: 1, IncludeRaces: true})
+	if err != nil {
+		t.Fatalf("GetMeeting: %v", err)
+	}
+	var numbers []int64
+	for _, race := range resp.Races {
+		numbers = append(numbers, race.Number)
+	}
+	if !reflect.DeepEqual(numbers, []int64{1, 2, 3}) {
+		t.Errorf("race numbers = %v, want 1, 2 and 3", numbers)
+	}
+
+	if _, err := s.GetMeeting(ctx, &racing.GetMeetingRequest{MeetingId: 99, IncludeRaces: true}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetMeeting of a missing meeting: err = %v, want NotFound", err)
+	}
+}
+
+// fieldStrings renders field changes as text, to compare them.
+func fieldStrings(fields []*racing.FieldChange) []string {
+	var s []string
+	for _, field := range fields {
+		s = append(s, field.Field+": "+field.OldValue+" -> "+field.NewValue)
+	}
+	return s
+}
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// watchBufferSize is the number of changes buffered for each watcher. Rather
+// than hold up writers, a watcher with this many changes pending is
+// disconnected, and must watch again to resynchronise.
+const watchBufferSize = 256
+
+// errWatcherBehind is returned to a watcher disconnected for falling behind.
+var errWatcherBehind = status.Error(codes.ResourceExhausted, "watcher fell too far behind, watch again to resynchronise")
+
+// closeRetryInterval is how long the races closing next are left before
+// being looked for again, after failing to find them.
+const closeRetryInterval = 10 * time.Second
+
+// raceWatchers fans out the IDs of changed races to every watcher.
+//
+// Open races close once their advertised start time has passed, without
+// anything being written. While there are watchers, a single timer runs
+// until the next race closes, and the IDs of the races closing are then
+// published as if they had been written.
+type raceWatchers struct {
+	clock db.Clock
+	// closing returns the instant the next open races close, and their IDs,
+	// or the zero time if no race is open.
+	closing func() (time.Time, []int64, error)
+
+	mu       sync.Mutex
+	watchers map[chan int64]struct{}
+	// stop stops the close timer, and is nil while there are no watchers.
+	stop chan struct{}
+	// wake tells the close timer that races may have changed, so that the
+	// next to close must be looked for again.
+	wake chan struct{}
+}
+
+func newRaceWatchers(clock db.Clock, closing func() (time.Time, []int64, error)) *raceWatchers {
+	return &raceWatchers{
+		clock:    clock,
+		closing:  closing,
+		watchers: make(map[chan int64]struct{}),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// subscribe registers a new watcher, returning the channel the IDs of changed
+// races are delivered on. The channel is closed if the watcher falls behind.
+func (w *raceWatchers) subscribe() chan int64 {
+	ch := make(chan int64, watchBufferSize)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.watchers[ch] = struct{}{}
+	if w.stop == nil {
+		w.stop = make(chan struct{})
+		go w.watchCloses(w.stop)
+	}
+
+	return ch
+}
+
+func (w *raceWatchers) unsubscribe(ch chan int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.remove(ch)
+}
+
+// remove drops a watcher, stopping the close timer once there are none left.
+// It is called with mu held.
+func (w *raceWatchers) remove(ch chan int64) {
+	if _, ok := w.watchers[ch]; !ok {
+		return
+	}
+
+	delete(w.watchers, ch)
+	close(ch)
+
+	if len(w.watchers) == 0 {
+		close(w.stop)
+		w.stop = nil
+	}
+}
+
+// publish notifies every watcher that a race may have changed. It never
+// blocks, dropping any watcher whose buffer is full.
+func (w *raceWatchers) publish(raceId int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for ch := range w.watchers {
+		select {
+		case ch <- raceId:
+		default:
+			w.remove(ch)
+		}
+	}
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// watchCloses publishes the IDs of races as they close, until stopped.
+func (w *raceWatchers) watchCloses(stop chan struct{}) {
+	// fired is the last instant races were published as closing at. Races
+	// are never published as closing at it again, so that a race found
+	// still open once it should have closed is not published over and over.
+	var fired time.Time
+
+	for {
+		// Any change published until now, including the closes just
+		// published, is seen by looking again.
+		select {
+		case <-w.wake:
+		default:
+		}
+
+		at, ids, err := w.closing()
+		if err != nil {
+			log.Printf("failed finding the next races to close: %s\n", err)
+			at, ids = w.clock.Now().Add(closeRetryInterval), nil
+		}
+
+		var (
+			timer  *time.Timer
+			closed <-chan time.Time
+		)
+		if !at.IsZero() && at.After(fired) {
+			timer = time.NewTimer(at.Sub(w.clock.Now()))
+			closed = timer.C
+		}
+
+		select {
+		case <-stop:
+		case <-w.wake:
+		case <-closed:
+			fired = at
+			for _, id := range ids {
+				w.publish(id)
+			}
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+
+		select {
+		case <-stop:
+			return
+		default:
+		}
+	}
+}
+
+// raceWatch tracks the races a single watcher has been sent.
+type raceWatch struct {
+	filter *racing.ListRacesRequestFilter
+	stream racing.Racing_WatchRacesServer
+	races  map[int64]*racing.Race
+}
+
+func (w *raceWatch) send(typ racing.WatchRacesResponse_Type, race *racing.Race) error {
+	return w.stream.Send(&racing.WatchRacesResponse{Type: typ, Race: race})
+}
+
+func (s *racingService) WatchRaces(in *racing.WatchRacesRequest, stream racing.Racing_WatchRacesServer) error {
+	// Subscribe before taking the snapshot, so that no change made while it
+	// is being taken is missed.
+	changes := s.watchers.subscribe()
+	defer s.watchers.unsubscribe(changes)
+
+	w := &raceWatch{
+		filter: in.GetFilter(),
+		stream: stream,
+		races:  make(map[int64]*racing.Race),
+	}
+	if w.filter == nil {
+		w.filter = &racing.ListRacesRequestFilter{}
+	}
+
+	snapshot, err := s.listAllRaces(&racing.ListRacesRequest{Filter: w.filter})
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	for _, race := range snapshot {
+		w.races[race.Id] = race
+		if err := w.send(racing.WatchRacesResponse_SNAPSHOT, race); err != nil {
+			return err
+		}
+	}
+
+	if err := w.send(racing.WatchRacesResponse_SNAPSHOT_COMPLETE, nil); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case id, ok := <-changes:
+			if !ok {
+				return errWatcherBehind
+			}
+
+			ids, ok := drainChanges(changes, id)
+			if !ok {
+				return errWatcherBehind
+			}
+
+			if err := s.syncWatch(w, ids); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// drainChanges collects the IDs of every change pending for a watcher, so
+// they are synchronised together. It reports false if the watcher has been
+// dropped.
+func drainChanges(changes chan int64, id int64) ([]int64, bool) {
+	ids := []int64{id}
+
+	for {
+		select {
+		case id, ok := <-changes:
+			if !ok {
+				return nil, false
+			}
+			ids = append(ids, id)
+		default:
+			return ids, true
+		}
+	}
+}
+
+// nextRaceCloses returns the instant the next open races close, and the IDs
+// of every race closing then, or the zero time if no race is open.
+func (s *racingService) nextRaceCloses() (time.Time, []int64, error) {
+	open := []racing.RaceStatus{racing.RaceStatus_OPEN}
+	idOnly := &field_mask.FieldMask{Paths: []string{"id"}}
+
+	next, _, err := s.racesRepo.List(&racing.ListRacesRequest{
+		Filter:   &racing.ListRacesRequestFilter{Statuses: open},
+		OrderBy:  []*racing.RaceOrder{{Field: racing.RaceOrder_ADVERTISED_START_TIME, Direction: racing.RaceOrder_ASC}},
+		PageSize: 1,
+		ReadMask: idOnly,
+	})
+	if err != nil || len(next) == 0 {
+		return time.Time{}, nil, err
+	}
+
+	// A race is open up to and including its advertised start time, to the
+	// millisecond.
+	start := next[0].AdvertisedStartTime.AsTime()
+	closes := start.Add(time.Millisecond)
+	from, _ := ptypes.TimestampProto(start)
+	to, _ := ptypes.TimestampProto(closes)
+
+	races, err := s.listAllRaces(&racing.ListRacesRequest{
+		Filter:   &racing.ListRacesRequestFilter{Statuses: open, AdvertisedStartFrom: from, AdvertisedStartTo: to},
+		ReadMask: idOnly,
+	})
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	var ids []int64
+	for _, race := range races {
+		ids = append(ids, race.Id)
+	}
+
+	return closes, ids, nil
+}
+
+// syncWatch re-reads the given races and sends an event for each that differs
+// from what the watcher was last sent.
+func (s *racingService) syncWatch(w *raceWatch, ids []int64) error {
+	filter := proto.Clone(w.filter).(*racing.ListRacesRequestFilter)
+
+	checked := ids
+
+	// Races outside of the filter's own IDs can never match it.
+	if len(filter.Ids) > 0 {
+		ids = intersectIds(filter.Ids, ids)
+		if len(ids) == 0 {
+			return nil
+		}
+	}
+	filter.Ids = ids
+
+	races, err := s.listAllRaces(&racing.ListRacesRequest{Filter: filter})
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	matched := make(map[int64]bool)
+	for _, race := range races {
+		matched[race.Id] = true
+
+		typ := racing.WatchRacesResponse_UPDATED
+		if prev, ok := w.races[race.Id]; !ok {
+			typ = racing.WatchRacesResponse_CREATED
+		} else if proto.Equal(prev, race) {
+			continue
+		}
+
+		w.races[race.Id] = race
+		if err := w.send(typ, race); err != nil {
+			return err
+		}
+	}
+
+	sort.Slice(checked, func(i, j int) bool { return checked[i] < checked[j] })
+	for _, id := range checked {
+		prev, ok := w.races[id]
+		if !ok || matched[id] {
+			continue
+		}
+
+		delete(w.races, id)
+		if err := w.send(racing.WatchRacesResponse_DELETED, prev); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// intersectIds returns the IDs of b that are also in a.
+func intersectIds(a, b []int64) []int64 {
+	in := make(map[int64]bool, len(a))
+	for _, id := range a {
+		in[id] = true
+	}
+
+	var ids []int64
+	for _, id := range b {
+		if in[id] {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/db/dbtest"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// watchTimeout is how long a test waits for a watcher to be sent something.
+const watchTimeout = 5 * time.Second
+
+// watchStream is a WatchRaces stream whose responses are delivered to sent,
+// each send blocking until it is received.
+type watchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *racing.WatchRacesResponse
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStream) Send(resp *racing.WatchRacesResponse) error {
+	select {
+	case s.sent <- resp:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+// next returns the next response sent to the watcher.
+func (s *watchStream) next(t *testing.T) *racing.WatchRacesResponse {
+	t.Helper()
+
+	select {
+	case resp := <-s.sent:
+		return resp
+	case <-time.After(watchTimeout):
+		t.Fatal("timed out waiting for a watch response")
+		return nil
+	}
+}
+
+// expect checks the next response sent to the watcher is of the given type,
+// for the race with the given ID, returning the race.
+func (s *watchStream) expect(t *testing.T, typ racing.WatchRacesResponse_Type, raceId int64) *racing.Race {
+	t.Helper()
+
+	resp := s.next(t)
+	if resp.Type != typ || resp.Race.GetId() != raceId {
+		t.Fatalf("response = %v, want %s of race %d", resp, typ, raceId)
+	}
+
+	return resp.Race
+}
+
+// newWatchService creates a service over an in-memory races repository
+// holding races, of the meetings of the conformance suite's fixture, with
+// status derived from the system time.
+func newWatchService(races ...*racing.Race) *racingService {
+	repo := db.NewMemoryRacesRepo(db.SystemClock, db.RacesFixture{Meetings: dbtest.RacesFixture().Meetings, Races: races})
+	return NewRacingService(repo, nil, nil, nil, nil, db.SystemClock).(*racingService)
+}
+
+// watch starts watching the races matching filter, stopping when the test
+// ends. The error the watch ends with is delivered on the returned channel.
+func watch(t *testing.T, s *racingService, filter *racing.ListRacesRequestFilter) (*watchStream, <-chan error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &watchStream{ctx: ctx, sent: make(chan *racing.WatchRacesResponse)}
+
+	var (
+		errc = make(chan error, 1)
+		done = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		errc <- s.WatchRaces(&racing.WatchRacesRequest{Filter: filter}, stream)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	return stream, errc
+}
+
+// startingIn returns the instant d from now.
+func startingIn(d time.Duration) *timestamp.Timestamp {
+	ts, _ := ptypes.TimestampProto(time.Now().Add(d))
+	return ts
+}
+
+func TestWatchRacesSnapshot(t *testing.T) {
+	s := newWatchService(
+		&racing.Race{Id: 1, MeetingId: 1, Name: "Flemington Cup", Number: 1, Visible: true, AdvertisedStartTime: startingIn(time.Hour)},
+		&racing.Race{Id: 2, MeetingId: 1, Name: "Melbourne Stakes", Number: 2, AdvertisedStartTime: startingIn(2 * time.Hour)},
+		&racing.Race{Id: 3, MeetingId: 2, Name: "Wentworth Sprint", Number: 1, Visible: true, AdvertisedStartTime: startingIn(3 * time.Hour)},
+	)
+
+	// The snapshot holds only the races matching the filter.
+	stream, _ := watch(t, s, &racing.ListRacesRequestFilter{VisibleOnly: true})
+	stream.expect(t, racing.WatchRacesResponse_SNAPSHOT, 3)
+	stream.expect(t, racing.WatchRacesResponse_SNAPSHOT, 1)
+	if resp := stream.next(t); resp.Type != racing.WatchRacesResponse_SNAPSHOT_COMPLETE || resp.Race != nil {
+		t.Errorf("response = %v, want SNAPSHOT_COMPLETE", resp)
+	}
+}
+
+func TestWatchRacesChanges(t *testing.T) {
+	s := newWatchService(
+		&racing.Race{Id: 1, MeetingId: 1, Name: "Flemington Cup", Number: 1, Visible: true, AdvertisedStartTime: startingIn(time.Hour)},
+	)
+	ctx := context.Background()
+
+	stream, _ := watch(t, s, &racing.ListRacesRequestFilter{VisibleOnly: true})
+	stream.expect(t, racing.WatchRacesResponse_SNAPSHOT, 1)
+	stream.expect(t, racing.WatchRacesResponse_SNAPSHOT_COMPLETE, 0)
+
+	if _, err := s.CreateRace(ctx, &racing.CreateRaceRequest{Race: &racing.Race{MeetingId: 1, Name: "Maiden Plate", Number: 2, Visible: true, AdvertisedStartTime: startingIn(2 * time.Hour)}}); err != nil {
+		t.Fatalf("CreateRace: %v", err)
+	}
+	stream.expect(t, racing.WatchRacesResponse_CREATED, 2)
+
+	if _, err := s.UpdateRace(ctx, &racing.UpdateRaceRequest{Race: &racing.Race{Id: 2, Name: "Maiden Handicap"}, UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}}}); err != nil {
+		t.Fatalf("UpdateRace: %v", err)
+	}
+	if race := stream.expect(t, racing.WatchRacesResponse_UPDATED, 2); race.Name != "Maiden Handicap" {
+		t.Errorf("updated race = %v, want it renamed", race)
+	}
+
+	// A race no longer matching the filter is deleted from the watch, and
+	// one never matching it is never sent.
+	if _, err := s.UpdateRace(ctx, &racing.UpdateRaceRequest{Race: &racing.Race{Id: 2}, UpdateMask: &field_mask.FieldMask{Paths: []string{"visible"}}}); err != nil {
+		t.Fatalf("UpdateRace: %v", err)
+	}
+	stream.expect(t, racing.WatchRacesResponse_DELETED, 2)
+
+	if _, err := s.CreateRace(ctx, &racing.CreateRaceRequest{Race: &racing.Race{MeetingId: 1, Name: "Hidden Stakes", Number: 3, AdvertisedStartTime: startingIn(3 * time.Hour)}}); err != nil {
+		t.Fatalf("CreateRace: %v", err)
+	}
+
+	if _, err := s.DeleteRace(ctx, &racing.DeleteRaceRequest{RaceId: 1}); err != nil {
+		t.Fatalf("DeleteRace: %v", err)
+	}
+	stream.expect(t, racing.WatchRacesResponse_DELETED, 1)
+}
+
+func TestWatchRacesDropsWatcherBehind(t *testing.T) {
+	s := newWatchService(
+		&racing.Race{Id: 1, MeetingId: 1, Name: "Flemington Cup", Number: 1, Visible: true, AdvertisedStartTime: startingIn(time.Hour)},
+	)
+
+	stream, errc := watch(t, s, nil)
+	stream.expect(t, racing.WatchRacesResponse_SNAPSHOT, 1)
+	stream.expect(t, racing.WatchRacesResponse_SNAPSHOT_COMPLETE, 0)
+
+	// The watcher blocks sending the update, not having it received, while
+	// more changes are published than it buffers. However many it drained
+	// before blocking, the rest overflow its buffer.
+	if _, err := s.UpdateRace(context.Background(), &racing.UpdateRaceRequest{Race: &racing.Race{Id: 1, Name: "Melbourne Cup"}, UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}}}); err != nil {
+		t.Fatalf("UpdateRace: %v", err)
+	}
+	for i := 0; i < 2*watchBufferSize+2; i++ {
+		s.watchers.publish(1)
+	}
+
+	// Whether or not the update is sent first, depending on whether the
+	// watcher had begun sending it, the watcher is dropped.
+	timeout := time.After(watchTimeout)
+	for {
+		select {
+		case resp := <-stream.sent:
+			if resp.Type != racing.WatchRacesResponse_UPDATED || resp.Race.Id != 1 {
+				t.Fatalf("response = %v, want UPDATED of race 1", resp)
+			}
+		case err := <-errc:
+			if status.Code(err) != codes.ResourceExhausted {
+				t.Errorf("WatchRaces: err = %v, want ResourceExhausted", err)
+			}
+			return
+		case <-timeout:
+			t.Fatal("timed out waiting for the watcher to be dropped")
+		}
+	}
+}
+
+func TestWatchRacesCloses(t *testing.T) {
+	s := newWatchService(
+		&racing.Race{Id: 1, MeetingId: 1, Name: "Flemington Cup", Number: 1, Visible: true, AdvertisedStartTime: startingIn(200 * time.Millisecond)},
+		&racing.Race{Id: 2, MeetingId: 1, Name: "Melbourne Stakes", Number: 2, Visible: true, AdvertisedStartTime: startingIn(time.Hour)},
+	)
+
+	// Once its start time passes, the race is sent as closed, without
+	// anything having been written, and leaves a watch of open races.
+	open, _ := watch(t, s, &racing.ListRacesRequestFilter{Statuses: []racing.RaceStatus{racing.RaceStatus_OPEN}})
+	all, _ := watch(t, s, nil)
+
+	for _, stream := range []*watchStream{open, all} {
+		stream.expect(t, racing.WatchRacesResponse_SNAPSHOT, 2)
+		stream.expect(t, racing.WatchRacesResponse_SNAPSHOT, 1)
+		stream.expect(t, racing.WatchRacesResponse_SNAPSHOT_COMPLETE, 0)
+	}
+
+	if race := all.expect(t, racing.WatchRacesResponse_UPDATED, 1); race.Status != racing.RaceStatus_CLOSED {
+		t.Errorf("race = %v, want it CLOSED", race)
+	}
+	open.expect(t, racing.WatchRacesResponse_DELETED, 1)
+}
+
+func TestWatchRacesArchives(t *testing.T) {
+	s := newWatchService(
+		&racing.Race{Id: 1, MeetingId: 1, Name: "Flemington Cup", Number: 1, Visible: true, Status: racing.RaceStatus_FINAL, AdvertisedStartTime: startingIn(-2 * time.Hour)},
+	)
+
+	// An archived race leaves a watch, unless it asks for archived races,
+	// to which it is sent as updated.
+	current, _ := watch(t, s, nil)
+	archived, _ := watch(t, s, &racing.ListRacesRequestFilter{IncludeArchived: true})
+
+	for _, stream := range []*watchStream{current, archived} {
+		stream.expect(t, racing.WatchRacesResponse_SNAPSHOT, 1)
+		stream.expect(t, racing.WatchRacesResponse_SNAPSHOT_COMPLETE, 0)
+	}
+
+	n, err := s.ArchiveRaces(time.Hour, 10, db.Audit{Actor: "retention", Reason: "testing"})
+	if err != nil {
+		t.Fatalf("ArchiveRaces: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("archived %d races, want 1", n)
+	}
+
+	current.expect(t, racing.WatchRacesResponse_DELETED, 1)
+	if race := archived.expect(t, racing.WatchRacesResponse_UPDATED, 1); race.ArchivedTime == nil {
+		t.Errorf("race = %v, want it archived", race)
+	}
+}
@@ -0,0 +1,8 @@
+module git.neds.sh/matty/entain/shared
+
+go 1.16
+
+require (
+	github.com/mattn/go-sqlite3 v1.14.6
+	google.golang.org/protobuf v1.25.1-0.20201208041424-160c7477e0e8
+)
@@ -0,0 +1,191 @@
+// Package migrate applies the ordered schema changes of a service's
+// database, recording each in a schema_version table as it is applied.
+package migrate
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrSchemaTooNew is returned when a database has been migrated by a newer
+// build, to a schema this build does not know.
+var ErrSchemaTooNew = errors.New("schema is newer than this build supports")
+
+// Migration is a single, ordered change to the schema of a database.
+type Migration struct {
+	// Version orders the migration. Versions are never reused.
+	Version int
+	Name    string
+	Up      func(tx *sql.Tx) error
+}
+
+// Status describes a migration and whether it has been applied.
+type Status struct {
+	Version int
+	Name    string
+	// Applied is when the migration was applied, or the zero time if it is
+	// pending.
+	Applied time.Time
+	// Known reports whether this build knows the migration. An applied
+	// migration that is not known was applied by a newer build.
+	Known bool
+}
+
+// Migrate applies each pending one of migrations in order, returning those
+// applied.
+// Each migration is applied in its own transaction, along with the record of
+// it having been applied. A database already migrated beyond the newest
+// migration known is left untouched, and ErrSchemaTooNew returned.
+func Migrate(db *sql.DB, migrations []Migration) ([]Status, error) {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (version INTEGER PRIMARY KEY, name TEXT NOT NULL, applied_time DATETIME NOT NULL)`); err != nil {
+		return nil, err
+	}
+
+	statuses, err := SchemaStatus(db, migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkSchemaKnown(statuses, migrations); err != nil {
+		return nil, err
+	}
+
+	var applied []Status
+	for i, m := range migrations {
+		if !statuses[i].Applied.IsZero() {
+			continue
+		}
+
+		status, err := applyMigration(db, m)
+		if err != nil {
+			return applied, fmt.Errorf("migration %d %s: %w", m.Version, m.Name, err)
+		}
+		applied = append(applied, status)
+	}
+
+	return applied, nil
+}
+
+// SchemaStatus reports each of migrations in order, followed by any applied
+// migrations that are not among them, without changing the database.
+func SchemaStatus(db *sql.DB, migrations []Migration) ([]Status, error) {
+	var exists bool
+	err := db.QueryRow(`SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_version')`).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]Status)
+	if exists {
+		rows, err := db.Query(`SELECT version, name, applied_time FROM schema_version ORDER BY version`)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var status Status
+			if err := rows.Scan(&status.Version, &status.Name, &status.Applied); err != nil {
+				return nil, err
+			}
+			applied[status.Version] = status
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	var statuses []Status
+	for _, m := range migrations {
+		status := Status{Version: m.Version, Name: m.Name, Known: true}
+		if a, ok := applied[m.Version]; ok {
+			status.Applied = a.Applied
+			delete(applied, m.Version)
+		}
+		statuses = append(statuses, status)
+	}
+
+	var unknown []Status
+	for _, status := range applied {
+		unknown = append(unknown, status)
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Version < unknown[j].Version })
+
+	return append(statuses, unknown...), nil
+}
+
+// checkSchemaKnown returns ErrSchemaTooNew if any applied migration is not
+// known to this build.
+func checkSchemaKnown(statuses []Status, migrations []Migration) error {
+	for _, status := range statuses {
+		if !status.Known {
+			return fmt.Errorf("%w: migration %d %s is applied, but the newest known is %d", ErrSchemaTooNew, status.Version, status.Name, migrations[len(migrations)-1].Version)
+		}
+	}
+
+	return nil
+}
+
+// applyMigration applies a single migration, recording that it was applied.
+func applyMigration(db *sql.DB, m Migration) (Status, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return Status{}, err
+	}
+	defer tx.Rollback()
+
+	if err := m.Up(tx); err != nil {
+		return Status{}, err
+	}
+
+	// Migrations are recorded against the system time, not a Clock, as
+	// they are applied in the real world whatever time the service reads at.
+	status := Status{Version: m.Version, Name: m.Name, Applied: time.Now().UTC().Truncate(time.Second), Known: true}
+	if _, err := tx.Exec(`INSERT INTO schema_version (version, name, applied_time) VALUES (?, ?, ?)`, m.Version, m.Name, status.Applied.Format(time.RFC3339)); err != nil {
+		return Status{}, err
+	}
+
+	return status, tx.Commit()
+}
+
+// ExecAll returns a migration step executing each statement in turn.
+func ExecAll(statements ...string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		for _, statement := range statements {
+			if _, err := tx.Exec(statement); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// AddColumnIfMissing adds a column to a table, unless it already exists.
+func AddColumnIfMissing(tx *sql.Tx, table, column, definition string) error {
+	rows, err := tx.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`ALTER TABLE ` + table + ` ADD COLUMN ` + column + ` ` + definition)
+	return err
+}
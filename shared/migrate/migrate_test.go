@@ -0,0 +1,99 @@
+package migrate
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// testMigrations create a table and then add a column to it, which is
+// added only if missing.
+var testMigrations = []Migration{
+	{Version: 1, Name: "things", Up: ExecAll(`CREATE TABLE things (id INTEGER PRIMARY KEY)`)},
+	{Version: 3, Name: "things_name", Up: func(tx *sql.Tx) error {
+		return AddColumnIfMissing(tx, "things", "name", `TEXT NOT NULL DEFAULT ''`)
+	}},
+}
+
+func TestMigrate(t *testing.T) {
+	db := emptyDB(t)
+
+	applied, err := Migrate(db, testMigrations[:1])
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if len(applied) != 1 || applied[0].Version != 1 {
+		t.Fatalf("applied %+v, want migration 1", applied)
+	}
+
+	// Only migrations not yet applied are applied.
+	applied, err = Migrate(db, testMigrations)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if len(applied) != 1 || applied[0].Version != 3 || applied[0].Applied.IsZero() {
+		t.Fatalf("applied %+v, want migration 3", applied)
+	}
+	if _, err := db.Exec(`INSERT INTO things (id, name) VALUES (1, 'one')`); err != nil {
+		t.Errorf("inserting with the added column: %v", err)
+	}
+
+	statuses, err := SchemaStatus(db, testMigrations)
+	if err != nil {
+		t.Fatalf("SchemaStatus: %v", err)
+	}
+	for i, status := range statuses {
+		if status.Version != testMigrations[i].Version || status.Applied.IsZero() || !status.Known {
+			t.Errorf("status %d = %+v, want migration %d applied", i, status, testMigrations[i].Version)
+		}
+	}
+	if statuses[1].Applied != applied[0].Applied {
+		t.Errorf("applied time = %v, want %v as recorded", statuses[1].Applied, applied[0].Applied)
+	}
+
+	// A build knowing fewer migrations leaves the database alone.
+	if _, err := Migrate(db, testMigrations[:1]); !errors.Is(err, ErrSchemaTooNew) {
+		t.Errorf("Migrate: err = %v, want ErrSchemaTooNew", err)
+	}
+}
+
+func TestMigrateFailure(t *testing.T) {
+	db := emptyDB(t)
+
+	failing := append([]Migration(nil), testMigrations...)
+	failing[1].Up = ExecAll(`ALTER TABLE things ADD COLUMN name TEXT`, `NOT SQL`)
+
+	applied, err := Migrate(db, failing)
+	if err == nil {
+		t.Fatal("Migrate of a failing migration succeeded")
+	}
+	if len(applied) != 1 || applied[0].Version != 1 {
+		t.Errorf("applied %+v, want migration 1 before the failure", applied)
+	}
+
+	// The failed migration is rolled back whole, and left pending.
+	statuses, err := SchemaStatus(db, failing)
+	if err != nil {
+		t.Fatalf("SchemaStatus: %v", err)
+	}
+	if !statuses[1].Applied.IsZero() {
+		t.Errorf("status = %+v, want migration 3 pending", statuses[1])
+	}
+	if _, err := Migrate(db, testMigrations); err != nil {
+		t.Errorf("Migrate after the failure: %v", err)
+	}
+}
+
+// emptyDB opens an empty database, closed at the end of the test.
+func emptyDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
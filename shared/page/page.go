@@ -0,0 +1,133 @@
+// Package page encodes the opaque tokens list requests are paged by, as
+// each service's repositories page theirs. Errors are returned as values the
+// repositories report against the page_size or page_token field.
+package page
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultSize is used when a caller does not ask for a page size.
+	DefaultSize = 100
+	// MaxSize is the most items returned in one page. Larger requested sizes
+	// are coerced down to it.
+	MaxSize = 500
+)
+
+var (
+	// ErrNegativeSize is returned for a negative page size.
+	ErrNegativeSize = errors.New("must not be negative")
+
+	// ErrMalformedToken is returned for a page token that could not have
+	// been issued, or whose keys cannot be decoded.
+	ErrMalformedToken = errors.New("malformed page token")
+
+	// ErrRequestMismatch is returned for a page token issued for a request
+	// with a different filter or ordering.
+	ErrRequestMismatch = errors.New("page token does not match request")
+
+	// ErrOrderMismatch is returned for a page token recording a different
+	// number of keys than the request is ordered by.
+	ErrOrderMismatch = errors.New("page token does not match ordering")
+)
+
+// token is the decoded form of an opaque page token. It records the ordering
+// keys of the last item returned, so the next page resumes strictly after it
+// regardless of rows inserted in the meantime.
+type token struct {
+	// Checksum guards against a token being replayed against a request with
+	// a different filter or ordering.
+	Checksum uint32            `json:"c"`
+	Keys     []json.RawMessage `json:"k"`
+}
+
+// Size validates a requested page size, applying the default and max.
+func Size(requested int32) (int, error) {
+	switch {
+	case requested < 0:
+		return 0, ErrNegativeSize
+	case requested == 0:
+		return DefaultSize, nil
+	case requested > MaxSize:
+		return MaxSize, nil
+	}
+
+	return int(requested), nil
+}
+
+// Checksum fingerprints the parts of a list request that must not change
+// between pages: the request, less its paging fields, and the terms it is
+// ordered by.
+func Checksum(request proto.Message, order []string) uint32 {
+	h := fnv.New32a()
+
+	b, _ := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	h.Write(b)
+
+	for _, term := range order {
+		h.Write([]byte(term + ","))
+	}
+
+	return h.Sum32()
+}
+
+// EncodeToken encodes a page token resuming after the item with keys.
+func EncodeToken(checksum uint32, keys []interface{}) (string, error) {
+	t := token{Checksum: checksum}
+
+	for _, key := range keys {
+		raw, err := json.Marshal(key)
+		if err != nil {
+			return "", err
+		}
+		t.Keys = append(t.Keys, raw)
+	}
+
+	b, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeToken decodes the keys recorded by a page token, which must have
+// been issued for a request with checksum.
+func DecodeToken(s string, checksum uint32) ([]json.RawMessage, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	var t token
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	if t.Checksum != checksum {
+		return nil, ErrRequestMismatch
+	}
+
+	return t.Keys, nil
+}
+
+// DecodeKeys decodes the keys of a page token into values, one for each.
+func DecodeKeys(keys []json.RawMessage, values ...interface{}) error {
+	if len(keys) != len(values) {
+		return ErrMalformedToken
+	}
+
+	for i, key := range keys {
+		if err := json.Unmarshal(key, values[i]); err != nil {
+			return ErrMalformedToken
+		}
+	}
+
+	return nil
+}
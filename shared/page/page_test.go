@@ -0,0 +1,95 @@
+package page
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestSize(t *testing.T) {
+	for _, tc := range []struct {
+		requested int32
+		want      int
+		err       error
+	}{
+		{-1, 0, ErrNegativeSize},
+		{0, DefaultSize, nil},
+		{7, 7, nil},
+		{MaxSize, MaxSize, nil},
+		{MaxSize + 1, MaxSize, nil},
+	} {
+		size, err := Size(tc.requested)
+		if size != tc.want || !errors.Is(err, tc.err) {
+			t.Errorf("Size(%d) = %d, %v, want %d, %v", tc.requested, size, err, tc.want, tc.err)
+		}
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	request := wrapperspb.String("cup")
+	checksum := Checksum(request, []string{"number ASC", "id ASC"})
+
+	if Checksum(wrapperspb.String("cup"), []string{"number ASC", "id ASC"}) != checksum {
+		t.Error("checksum of the same request differs")
+	}
+	for name, other := range map[string]uint32{
+		"different request":   Checksum(wrapperspb.String("final"), []string{"number ASC", "id ASC"}),
+		"different order":     Checksum(request, []string{"name ASC", "id ASC"}),
+		"different direction": Checksum(request, []string{"number DESC", "id ASC"}),
+		"fewer terms":         Checksum(request, []string{"number ASC"}),
+	} {
+		if other == checksum {
+			t.Errorf("checksum of a %s matches", name)
+		}
+	}
+}
+
+func TestToken(t *testing.T) {
+	const checksum = 42
+
+	token, err := EncodeToken(checksum, []interface{}{"2021-03-02T12:00:00Z", int64(7)})
+	if err != nil {
+		t.Fatalf("EncodeToken: %v", err)
+	}
+
+	keys, err := DecodeToken(token, checksum)
+	if err != nil {
+		t.Fatalf("DecodeToken: %v", err)
+	}
+	var (
+		start string
+		id    int64
+	)
+	if err := DecodeKeys(keys, &start, &id); err != nil {
+		t.Fatalf("DecodeKeys: %v", err)
+	}
+	if start != "2021-03-02T12:00:00Z" || id != 7 {
+		t.Errorf("keys = %q, %d, want the keys encoded", start, id)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"not base64", "!", ErrMalformedToken},
+		{"not JSON", base64.RawURLEncoding.EncodeToString([]byte("{")), ErrMalformedToken},
+		{"mistyped checksum", base64.RawURLEncoding.EncodeToString([]byte(`{"c":"42"}`)), ErrMalformedToken},
+		{"different checksum", base64.RawURLEncoding.EncodeToString([]byte(`{"c":43,"k":[7]}`)), ErrRequestMismatch},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := DecodeToken(tc.token, checksum); !errors.Is(err, tc.err) {
+				t.Errorf("DecodeToken: err = %v, want %v", err, tc.err)
+			}
+		})
+	}
+
+	if err := DecodeKeys(keys, &id); !errors.Is(err, ErrMalformedToken) {
+		t.Errorf("DecodeKeys of too few values: err = %v, want %v", err, ErrMalformedToken)
+	}
+	if err := DecodeKeys(keys, &id, &id); !errors.Is(err, ErrMalformedToken) {
+		t.Errorf("DecodeKeys of a mistyped key: err = %v, want %v", err, ErrMalformedToken)
+	}
+}
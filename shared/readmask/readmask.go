@@ -0,0 +1,36 @@
+// Package readmask trims responses to the fields a caller asked for, as each
+// service does with the read_mask of its requests.
+package readmask
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Apply clears every field of m not named by mask, whose paths the
+// repository has already validated. An empty mask leaves m whole.
+func Apply(m proto.Message, mask *fieldmaskpb.FieldMask) {
+	if len(mask.GetPaths()) == 0 {
+		return
+	}
+
+	keep := make(map[string]bool)
+	for _, path := range mask.GetPaths() {
+		keep[path] = true
+	}
+
+	msg := m.ProtoReflect()
+
+	var clear []protoreflect.FieldDescriptor
+	msg.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if !keep[string(fd.Name())] {
+			clear = append(clear, fd)
+		}
+		return true
+	})
+
+	for _, fd := range clear {
+		msg.Clear(fd)
+	}
+}
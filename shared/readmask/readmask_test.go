@@ -0,0 +1,29 @@
+package readmask
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestApply(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		paths []string
+		want  *durationpb.Duration
+	}{
+		{"no mask", nil, &durationpb.Duration{Seconds: 90, Nanos: 5}},
+		{"one field", []string{"seconds"}, &durationpb.Duration{Seconds: 90}},
+		{"every field", []string{"nanos", "seconds"}, &durationpb.Duration{Seconds: 90, Nanos: 5}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &durationpb.Duration{Seconds: 90, Nanos: 5}
+			Apply(m, &fieldmaskpb.FieldMask{Paths: tc.paths})
+			if !proto.Equal(m, tc.want) {
+				t.Errorf("masked = %v, want %v", m, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Package retention archives old data on a schedule, as each service does
+// with its own repository.
+package retention
+
+import (
+	"log"
+	"time"
+)
+
+// BatchSize is the most rows archived in a single transaction, so that
+// archiving a backlog never holds the database for long.
+const BatchSize = 500
+
+// Archiver archives up to limit rows, returning how many it archived.
+type Archiver func(limit int) (int, error)
+
+// Run archives, as soon as it is called and then every interval, for as long
+// as the service runs. Each time, every row there is to archive is archived,
+// in batches, and how many is logged, describing them as what.
+func Run(archive Archiver, interval time.Duration, what string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		archived, err := ArchiveAll(archive)
+		if archived > 0 {
+			log.Printf("archived %d %s\n", archived, what)
+		}
+		if err != nil {
+			log.Printf("failed archiving %s: %s\n", what, err)
+		}
+
+		<-ticker.C
+	}
+}
+
+// ArchiveAll archives in batches until there is nothing left to archive,
+// returning how many rows it archived.
+func ArchiveAll(archive Archiver) (int, error) {
+	var total int
+	for {
+		n, err := archive(BatchSize)
+		total += n
+		if err != nil || n < BatchSize {
+			return total, err
+		}
+	}
+}
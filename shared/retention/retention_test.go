@@ -0,0 +1,43 @@
+package retention
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestArchiveAll(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	for _, tc := range []struct {
+		name      string
+		batches   []int
+		err       error
+		want      int
+		wantCalls int
+	}{
+		{name: "nothing to archive", batches: []int{0}, want: 0, wantCalls: 1},
+		{name: "one short batch", batches: []int{3}, want: 3, wantCalls: 1},
+		{name: "backlog", batches: []int{BatchSize, BatchSize, 7}, want: 2*BatchSize + 7, wantCalls: 3},
+		{name: "backlog of whole batches", batches: []int{BatchSize, 0}, want: BatchSize, wantCalls: 2},
+		{name: "failure", batches: []int{BatchSize, 2}, err: errFailed, want: BatchSize, wantCalls: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int
+			archived, err := ArchiveAll(func(limit int) (int, error) {
+				if limit != BatchSize {
+					t.Errorf("limit = %d, want %d", limit, BatchSize)
+				}
+				n := tc.batches[calls]
+				calls++
+				return n, tc.err
+			})
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err = %v, want %v", err, tc.err)
+			}
+			if archived != tc.want || calls != tc.wantCalls {
+				t.Errorf("archived %d in %d batches, want %d in %d", archived, calls, tc.want, tc.wantCalls)
+			}
+		})
+	}
+}
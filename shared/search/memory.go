@@ -0,0 +1,135 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// In-memory repositories cannot use FTS5, so Match stands in for it. Names
+// are matched as the query built by MatchQuery matches them, but scored and
+// excerpted only closely enough that results are found and ordered as
+// SQLite's are for the names a test searches, not to reproduce bm25 scores
+// or snippet windows exactly.
+
+// diacriticFolds maps the lower case Latin-1 and Latin Extended-A letters
+// that carry diacritics onto their base letter, as remove_diacritics does.
+// Letters beyond Latin Extended-A keep their diacritics.
+var diacriticFolds = func() map[rune]rune {
+	var (
+		letters = []rune("àáâãäåçèéêëìíîïñòóôõöùúûüýÿāăąćĉċčďēĕėęěĝğġģĥĩīĭįĵķĺļľńņňōŏőŕŗřśŝşšţťũūŭůűųŵŷźżž")
+		bases   = []rune("aaaaaaceeeeiiiinooooouuuuyyaaaccccdeeeeegggghiiiijklllnnnooorrrssssttuuuuuuwyzzz")
+		folds   = make(map[rune]rune, len(letters))
+	)
+	for i, letter := range letters {
+		folds[letter] = bases[i]
+	}
+
+	return folds
+}()
+
+// Hit is a name matched by a search.
+type Hit struct {
+	// Score is lower the more relevant the match, as bm25 scores are.
+	Score float64
+	// Snippet is the name with each matched word marked.
+	Snippet string
+}
+
+// Match matches every name, keyed by ID, against the words of a search,
+// each of which must begin a word of a matching name, ignoring case and
+// diacritics. A match scores the share of the name's words matched, negated
+// so that, as with bm25, a match in a shorter name is more relevant.
+func Match(names map[int64]string, words []string) map[int64]Hit {
+	prefixes := make([]string, len(words))
+	for i, word := range words {
+		prefixes[i] = fold(word)
+	}
+
+	hits := make(map[int64]Hit)
+	for id, name := range names {
+		var (
+			snippet strings.Builder
+			tokens  int
+			matched int
+			seen    = make([]bool, len(prefixes))
+			last    int
+		)
+
+		for _, token := range tokenize(name) {
+			tokens++
+			snippet.WriteString(name[last:token[0]])
+			last = token[1]
+
+			word := name[token[0]:token[1]]
+			found := false
+			for i, prefix := range prefixes {
+				if strings.HasPrefix(fold(word), prefix) {
+					seen[i], found = true, true
+				}
+			}
+			if !found {
+				snippet.WriteString(word)
+				continue
+			}
+
+			matched++
+			snippet.WriteString("<mark>" + word + "</mark>")
+		}
+		snippet.WriteString(name[last:])
+
+		if !all(seen) {
+			continue
+		}
+		hits[id] = Hit{
+			Score:   -float64(matched) / float64(tokens),
+			Snippet: snippet.String(),
+		}
+	}
+
+	return hits
+}
+
+// tokenize returns the start and end byte offsets of each word of a name.
+func tokenize(name string) [][2]int {
+	var (
+		tokens [][2]int
+		start  = -1
+	)
+
+	for i, r := range name {
+		switch {
+		case isTokenRune(r) && start < 0:
+			start = i
+		case !isTokenRune(r) && start >= 0:
+			tokens = append(tokens, [2]int{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, [2]int{start, len(name)})
+	}
+
+	return tokens
+}
+
+// fold folds a word to the form it is indexed and matched in.
+func fold(word string) string {
+	return strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		if base, ok := diacriticFolds[r]; ok {
+			return base
+		}
+		return r
+	}, word)
+}
+
+// all reports whether every one of seen is true.
+func all(seen []bool) bool {
+	for _, s := range seen {
+		if !s {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,85 @@
+// Package search indexes names for full text search with SQLite's FTS5
+// extension, as the racing and sports services both search theirs.
+package search
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SnippetTokens is the most words of a name shown in a search snippet.
+const SnippetTokens = 16
+
+// CreateIndex creates an FTS5 index over the name column of table, kept in
+// step with it by triggers. An index created over a table that already has
+// rows is built from them.
+func CreateIndex(tx *sql.Tx, table string) error {
+	index := table + "_fts"
+
+	var exists bool
+	err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = ?)`, index).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	statements := []string{
+		`CREATE VIRTUAL TABLE IF NOT EXISTS ` + index + ` USING fts5(name, content = '` + table + `', content_rowid = 'id', tokenize = 'unicode61 remove_diacritics 2')`,
+		`CREATE TRIGGER IF NOT EXISTS ` + index + `_insert AFTER INSERT ON ` + table + ` BEGIN
+			INSERT INTO ` + index + `(rowid, name) VALUES (new.id, new.name);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS ` + index + `_delete AFTER DELETE ON ` + table + ` BEGIN
+			INSERT INTO ` + index + `(` + index + `, rowid, name) VALUES ('delete', old.id, old.name);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS ` + index + `_update AFTER UPDATE OF id, name ON ` + table + ` BEGIN
+			INSERT INTO ` + index + `(` + index + `, rowid, name) VALUES ('delete', old.id, old.name);
+			INSERT INTO ` + index + `(rowid, name) VALUES (new.id, new.name);
+		END`,
+	}
+	if !exists {
+		statements = append(statements, `INSERT INTO `+index+`(`+index+`) VALUES ('rebuild')`)
+	}
+
+	// An existing index is queried, as a build without FTS5 can open the
+	// database but fails on any use of the index, including by the triggers.
+	statements = append(statements, `SELECT rowid FROM `+index+` LIMIT 0`)
+
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			if strings.Contains(err.Error(), "no such module: fts5") {
+				return fmt.Errorf("%w: build with -tags sqlite_fts5 to enable search", err)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MatchQuery converts the text of a search into an FTS5 query matching
+// names with a word beginning with each of its words, so that names are
+// found as they are typed. Punctuation is dropped, as it is from indexed
+// names, leaving nothing FTS5 would parse as syntax. It returns an empty
+// query if the text has no words.
+func MatchQuery(text string) string {
+	words := Words(text)
+	for i, word := range words {
+		words[i] = `"` + word + `"*`
+	}
+
+	return strings.Join(words, " ")
+}
+
+// Words splits the text of a search into its words.
+func Words(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !isTokenRune(r)
+	})
+}
+
+// isTokenRune reports whether r is part of a word, rather than separating
+// words, as FTS5 tokenizes names.
+func isTokenRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
@@ -0,0 +1,67 @@
+package search
+
+import (
+	"testing"
+)
+
+func TestMatchQuery(t *testing.T) {
+	for _, tc := range []struct {
+		text string
+		want string
+	}{
+		{"cup", `"cup"*`},
+		{"  Flem  CUP ", `"Flem"* "CUP"*`},
+		{`pure_cup "OR" 100%`, `"pure"* "cup"* "OR"* "100"*`},
+		{" ?! ", ""},
+	} {
+		if got := MatchQuery(tc.text); got != tc.want {
+			t.Errorf("MatchQuery(%q) = %q, want %q", tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	names := map[int64]string{
+		1: "Flemington Cup",
+		2: "Melbourne Stakes",
+		3: "100% Pure_Cup",
+		4: "Cupid's Café",
+	}
+
+	for _, tc := range []struct {
+		name  string
+		words []string
+		want  map[int64]string
+	}{
+		{"prefix", []string{"cup"}, map[int64]string{
+			1: "Flemington <mark>Cup</mark>",
+			3: "100% Pure_<mark>Cup</mark>",
+			4: "<mark>Cupid</mark>'s Café",
+		}},
+		{"every word", []string{"FLÉM", "cu"}, map[int64]string{
+			1: "<mark>Flemington</mark> <mark>Cup</mark>",
+		}},
+		{"diacritics", []string{"cafe"}, map[int64]string{
+			4: "Cupid's <mark>Café</mark>",
+		}},
+		{"no match", []string{"cup", "stakes"}, map[int64]string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hits := Match(names, tc.words)
+			if len(hits) != len(tc.want) {
+				t.Errorf("hits = %v, want %v", hits, tc.want)
+			}
+			for id, snippet := range tc.want {
+				if hits[id].Snippet != snippet {
+					t.Errorf("snippet of %d = %q, want %q", id, hits[id].Snippet, snippet)
+				}
+			}
+		})
+	}
+
+	// A match in a shorter name is more relevant, and so scores lower.
+	hits := Match(names, []string{"cup"})
+	if !(hits[1].Score < hits[3].Score) {
+		t.Errorf("scores %v and %v, want the shorter name lower", hits[1].Score, hits[3].Score)
+	}
+}
@@ -1,42 +1,258 @@
 package db
 
 import (
+	"database/sql"
+	"fmt"
+	"math/rand"
 	"time"
 
-	"syreclabs.com/go/faker"
+	"github.com/golang/protobuf/ptypes"
+
+	"sports/proto/sports"
+)
+
+// SeedOptions configures the dummy data Seed generates. The same options
+// always generate the same data.
+type SeedOptions struct {
+	// Seed seeds every random choice made, such as sports and start times.
+	Seed int64
+	// Meetings is the number of meetings, each of a single sport.
+	Meetings int
+	// EventsPerMeeting is the number of events held at each meeting,
+	// numbered from 1.
+	EventsPerMeeting int
+	// From is the first day meetings are held on, and Days the number of
+	// days they are spread across.
+	From time.Time
+	Days int
+	// BatchSize is the most rows inserted in a single transaction.
+	BatchSize int
+	// Reset deletes any existing events before seeding. Without it, seeding
+	// a database which already holds events fails.
+	Reset bool
+	// Audit is who is seeding and why, recorded in the history of each event
+	// created or deleted. History is never reset.
+	Audit Audit
+}
+
+// SeedResult counts the rows Seed inserted.
+type SeedResult struct {
+	Events int
+}
+
+var (
+	// sportsList are the sports meetings are seeded for.
+	sportsList = []string{"Tennis", "Fencing", "Badminton", "Sportsketball", "Archery", "Caber Toss", "Football", "Soccer", "Competitive Crying", "Extreme Ironing", "Swimming", "Gymnastics", "Toe Wrestling", "Arguing"}
+	// levelList are the levels events are seeded at.
+	levelList = []string{"Amateur", "Youth", "University", "Semi-Professional", "Professional", "International"}
 )
 
-func (r *eventsRepo) seed() error {
-	statement, err := r.db.Prepare(`CREATE TABLE IF NOT EXISTS events (id INTEGER PRIMARY KEY, meeting_id INTEGER, name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME, level TEXT, sold_out INTEGER)`)
-	if err == nil {
-		_, err = statement.Exec()
+// Seed fills the events database with dummy events, for test and example
+// purposes, inserting them in transactions of at most opts.BatchSize rows.
+// Meetings are held on random days from opts.From, each holding its events
+// from morning, UTC, one to two hours apart.
+func Seed(db *sql.DB, opts SeedOptions) (*SeedResult, error) {
+	var invalid string
+	switch {
+	case opts.Meetings < 1:
+		invalid = "meetings must be positive"
+	case opts.EventsPerMeeting < 1:
+		invalid = "events per meeting must be positive"
+	case opts.Days < 1:
+		invalid = "days must be positive"
+	case opts.BatchSize < 1:
+		invalid = "batch size must be positive"
+	}
+	if invalid != "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidArgument, invalid)
+	}
+
+	now := SystemClock.Now()
+	if err := prepareSeed(db, opts.Reset, opts.Audit, now); err != nil {
+		return nil, err
+	}
+
+	var (
+		rng    = rand.New(rand.NewSource(opts.Seed))
+		batch  = &seedBatch{db: db, size: opts.BatchSize}
+		result SeedResult
+		from   = time.Date(opts.From.Year(), opts.From.Month(), opts.From.Day(), 0, 0, 0, 0, time.UTC)
+	)
+	defer batch.rollback()
+
+	for meetingId := 1; meetingId <= opts.Meetings; meetingId++ {
+		sport := sportsList[rng.Intn(len(sportsList))]
+		day := from.AddDate(0, 0, rng.Intn(opts.Days))
+
+		// The first event starts between 08:00 and 12:00.
+		start := day.Add(8*time.Hour + time.Duration(rng.Intn(17))*15*time.Minute)
+
+		for number := 1; number <= opts.EventsPerMeeting; number++ {
+			if number > 1 {
+				start = start.Add(time.Duration(4+rng.Intn(5)) * 15 * time.Minute)
+			}
+
+			advertisedStart, err := ptypes.TimestampProto(start)
+			if err != nil {
+				return nil, err
+			}
+
+			event := &sports.Event{
+				Id:                  int64((meetingId-1)*opts.EventsPerMeeting + number),
+				MeetingId:           int64(meetingId),
+				Name:                sport,
+				Number:              int64(number),
+				Visible:             rng.Intn(2) == 1,
+				AdvertisedStartTime: advertisedStart,
+				Level:               levelList[rng.Intn(len(levelList))],
+				SoldOut:             rng.Intn(2) == 1,
+				Etag:                "1",
+			}
+
+			err = batch.insert(`INSERT INTO events(id, meeting_id, name, number, visible, advertised_start_time, level, sold_out) VALUES (?,?,?,?,?,?,?,?)`,
+				event.Id, event.MeetingId, event.Name, event.Number, event.Visible, start.Format(time.RFC3339), event.Level, event.SoldOut)
+			if err != nil {
+				return nil, err
+			}
+			result.Events++
+
+			change, err := newEventChange(nil, event, opts.Audit, now)
+			if err != nil {
+				return nil, err
+			}
+			args, err := eventChangeArgs(change)
+			if err != nil {
+				return nil, err
+			}
+			if err := batch.insert(getEventQueries()[eventsHistoryInsert], args...); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	// Anonymous function used to select a changing element from a string array.
-	// Method is not random, but time will change quickly enough to give a variety
-	// of results without additional imports. Good enough for creating a fake database.
-	selectElement := func(arr []string) string {
-		return arr[time.Now().Nanosecond()%len(arr)]
+	if err := batch.commit(); err != nil {
+		return nil, err
 	}
 
-	sportsList := []string{"Tennis", "Fencing", "Badminton", "Sportsketball", "Archery", "Caber Toss", "Football", "Soccer", "Competitive Crying", "Extreme Ironing", "Swimming", "Gymnastics", "Toe Wrestling", "Arguing"}
-	levelList := []string{"Amateur", "Youth", "University", "Semi-Professional", "Professional", "International"}
+	return &result, nil
+}
+
+// prepareSeed checks the events table is empty, or empties it if reset is
+// set, so that seeding always produces the same rows. Each event deleted is
+// recorded in its history, attributed to audit.
+func prepareSeed(db *sql.DB, reset bool, audit Audit, now time.Time) error {
+	if reset {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
 
-	for i := 1; i <= 100; i++ {
-		statement, err = r.db.Prepare(`INSERT OR IGNORE INTO events(id, meeting_id, name, number, visible, advertised_start_time, level, sold_out) VALUES (?, ?,?,?,?,?,?,?)`)
-		if err == nil {
-			_, err = statement.Exec(
-				i,
-				faker.Number().Between(1, 10),
-				selectElement(sportsList),
-				faker.Number().Between(1, 12),
-				faker.Number().Between(0, 1),
-				faker.Time().Between(time.Now().AddDate(0, 0, -1), time.Now().AddDate(0, 0, 2)).Format(time.RFC3339),
-				selectElement(levelList),
-				faker.Number().Between(0, 1),
-			)
+		rows, err := tx.Query(getEventQueries()[eventsList])
+		if err != nil {
+			return err
 		}
+		events, err := scanEvents(rows, now, eventColumns)
+		rows.Close()
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if err := recordEventChange(tx, event, nil, audit, now); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec(`DELETE FROM events`); err != nil {
+			return err
+		}
+
+		return tx.Commit()
 	}
 
+	var exists bool
+	if err := db.QueryRow(`SELECT EXISTS (SELECT 1 FROM events)`).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: table events is not empty; reset to replace its rows", ErrFailedPrecondition)
+	}
+
+	return nil
+}
+
+// seedBatch inserts rows in transactions of at most size rows, preparing
+// each distinct statement once per transaction.
+type seedBatch struct {
+	db   *sql.DB
+	size int
+
+	tx         *sql.Tx
+	statements map[string]*sql.Stmt
+	rows       int
+}
+
+// insert executes query with args, beginning a transaction if none is open
+// and committing it once it holds size rows.
+func (b *seedBatch) insert(query string, args ...interface{}) error {
+	if b.tx == nil {
+		tx, err := b.db.Begin()
+		if err != nil {
+			return err
+		}
+		b.tx, b.statements, b.rows = tx, make(map[string]*sql.Stmt), 0
+	}
+
+	statement, ok := b.statements[query]
+	if !ok {
+		var err error
+		if statement, err = b.tx.Prepare(query); err != nil {
+			return err
+		}
+		b.statements[query] = statement
+	}
+
+	if _, err := statement.Exec(args...); err != nil {
+		return err
+	}
+
+	b.rows++
+	if b.rows == b.size {
+		return b.commit()
+	}
+
+	return nil
+}
+
+// commit commits the open transaction, if there is one.
+func (b *seedBatch) commit() error {
+	if b.tx == nil {
+		return nil
+	}
+
+	b.closeStatements()
+	err := b.tx.Commit()
+	b.tx = nil
+
 	return err
 }
+
+// rollback rolls back the open transaction, if there is one. Batches
+// already committed are kept.
+func (b *seedBatch) rollback() {
+	if b.tx == nil {
+		return
+	}
+
+	b.closeStatements()
+	b.tx.Rollback()
+	b.tx = nil
+}
+
+func (b *seedBatch) closeStatements() {
+	for _, statement := range b.statements {
+		statement.Close()
+	}
+	b.statements = nil
+}
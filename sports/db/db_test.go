@@ -0,0 +1,102 @@
+package db_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"sports/db"
+)
+
+func TestSeedIsReproducible(t *testing.T) {
+	opts := db.SeedOptions{
+		Seed:             42,
+		Meetings:         4,
+		EventsPerMeeting: 3,
+		From:             time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		Days:             3,
+		BatchSize:        7,
+		Audit:            db.Audit{Actor: "tester", Reason: "seeding"},
+	}
+
+	first, err := migratedDB(t)
+	if err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+	if _, err := db.Seed(first, opts); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+
+	// Seeding a database which already holds data fails, unless reset.
+	if _, err := db.Seed(first, opts); !errors.Is(err, db.ErrFailedPrecondition) {
+		t.Errorf("Seed of a seeded database: err = %v, want ErrFailedPrecondition", err)
+	}
+
+	second, err := migratedDB(t)
+	if err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+	opts.BatchSize = 1000
+	if _, err := db.Seed(second, opts); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+
+	opts.Reset = true
+	result, err := db.Seed(first, opts)
+	if err != nil {
+		t.Fatalf("Seed with reset: %v", err)
+	}
+	if result.Events != 12 {
+		t.Errorf("result = %+v, want 4 meetings of 3 events", result)
+	}
+
+	if got, want := dumpTable(t, first, "events"), dumpTable(t, second, "events"); !reflect.DeepEqual(got, want) {
+		t.Errorf("events differ between databases seeded alike")
+	}
+
+	// Events deleted by a reset are recorded in their history, along with
+	// each event created.
+	var created, deleted int
+	err = first.QueryRow(`SELECT COUNT(CASE action WHEN 'CREATED' THEN 1 END), COUNT(CASE action WHEN 'DELETED' THEN 1 END) FROM event_history WHERE actor = ?`, opts.Audit.Actor).Scan(&created, &deleted)
+	if err != nil {
+		t.Fatalf("querying event history: %v", err)
+	}
+	if created != 24 || deleted != 12 {
+		t.Errorf("event history holds %d creations and %d deletions, want 24 and 12", created, deleted)
+	}
+}
+
+// dumpTable returns every row of a table, in ID order, rendered as text.
+func dumpTable(t *testing.T, sportingDB *sql.DB, table string) []string {
+	rows, err := sportingDB.Query(`SELECT * FROM ` + table + ` ORDER BY id`)
+	if err != nil {
+		t.Fatalf("querying %s: %v", table, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		t.Fatalf("querying %s: %v", table, err)
+	}
+
+	var dump []string
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			t.Fatalf("scanning %s: %v", table, err)
+		}
+		dump = append(dump, fmt.Sprint(values...))
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("querying %s: %v", table, err)
+	}
+
+	return dump
+}
@@ -0,0 +1,650 @@
+// Package dbtest is a conformance suite for implementations of the events
+// repository, run against both the SQLite and in-memory repositories so that
+// either may stand in for the other.
+package dbtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/protobuf/proto"
+
+	"sports/db"
+	"sports/proto/sports"
+)
+
+// Now is the time the clock of every repository under test is fixed at.
+var Now = time.Date(2021, 3, 2, 12, 0, 0, 0, time.UTC)
+
+// NewEventsRepo creates the events repository under test, holding exactly
+// the data of fixture and deriving event status from clock.
+type NewEventsRepo func(t *testing.T, clock db.Clock, fixture db.EventsFixture) db.EventsRepo
+
+// EventsFixture returns the data the suite creates each repository with.
+// Relative to Now, its events are:
+//
+//	1  Tennis Open Final  meeting 1  number 1  visible    +1h   Professional
+//	2  Tennis Qualifier   meeting 1  number 2  visible    -1h   Amateur
+//	3  Soccer Cup         meeting 2  number 1  invisible  +2h   Youth
+//	4  Fencing Cup Final  meeting 2  number 3  visible    +30m  International
+//	5  Archery            meeting 3  number 2  visible    -3h   University
+//
+// Its history records events 2 and 1 being seeded a day before Now, and an
+// event 6 being seeded then deleted by a reset in between.
+func EventsFixture() db.EventsFixture {
+	seeded := func(id int64, action sports.EventChange_Action, fields ...string) *sports.EventChange {
+		change := &sports.EventChange{EventId: id, Action: action, Actor: "seeder", Reason: "seeded with seed 1", ChangeTime: at(-24 * time.Hour), Etag: "1"}
+		for i := 0; i < len(fields); i += 2 {
+			field := &sports.FieldChange{Field: fields[i], NewValue: fields[i+1]}
+			if action == sports.EventChange_DELETED {
+				field.OldValue, field.NewValue = field.NewValue, ""
+			}
+			change.Fields = append(change.Fields, field)
+		}
+		return change
+	}
+	history := []*sports.EventChange{
+		seeded(2, sports.EventChange_CREATED, "meeting_id", "1", "name", "Tennis Qualifier", "number", "2", "visible", "true", "level", "Amateur"),
+		seeded(6, sports.EventChange_CREATED, "meeting_id", "4", "name", "Arguing", "number", "1", "level", "Youth"),
+		seeded(6, sports.EventChange_DELETED, "meeting_id", "4", "name", "Arguing", "number", "1", "level", "Youth"),
+		seeded(1, sports.EventChange_CREATED, "meeting_id", "1", "name", "Tennis Open Final", "number", "1", "visible", "true", "level", "Professional"),
+	}
+	for i, change := range history {
+		change.Id = int64(i + 1)
+	}
+
+	return db.EventsFixture{
+		Events: []*sports.Event{
+			{Id: 1, MeetingId: 1, Name: "Tennis Open Final", Number: 1, Visible: true, AdvertisedStartTime: at(time.Hour), Level: "Professional"},
+			{Id: 2, MeetingId: 1, Name: "Tennis Qualifier", Number: 2, Visible: true, AdvertisedStartTime: at(-time.Hour), Level: "Amateur"},
+			{Id: 3, MeetingId: 2, Name: "Soccer Cup", Number: 1, Visible: false, AdvertisedStartTime: at(2 * time.Hour), Level: "Youth"},
+			{Id: 4, MeetingId: 2, Name: "Fencing Cup Final", Number: 3, Visible: true, AdvertisedStartTime: at(30 * time.Minute), Level: "International", SoldOut: true},
+			{Id: 5, MeetingId: 3, Name: "Archery", Number: 2, Visible: true, AdvertisedStartTime: at(-3 * time.Hour), Level: "University"},
+		},
+		History: history,
+	}
+}
+
+// TestEventsRepo runs the conformance suite against the events repository
+// created by newRepo. Each test creates its own repository.
+func TestEventsRepo(t *testing.T, newRepo NewEventsRepo) {
+	clock := db.ClockFunc(func() time.Time { return Now })
+	repo := func(t *testing.T) db.EventsRepo {
+		t.Helper()
+		return newRepo(t, clock, EventsFixture())
+	}
+
+	t.Run("ListFilters", func(t *testing.T) {
+		testListFilters(t, repo(t))
+	})
+	t.Run("ListOrdering", func(t *testing.T) {
+		testListOrdering(t, repo(t))
+	})
+	t.Run("ListPaging", func(t *testing.T) {
+		testListPaging(t, repo(t))
+	})
+	t.Run("PageTokens", func(t *testing.T) {
+		testPageTokens(t, repo(t))
+	})
+	t.Run("StatusDerivation", func(t *testing.T) {
+		testStatusDerivation(t, repo(t))
+	})
+	t.Run("ReadMask", func(t *testing.T) {
+		testReadMask(t, repo(t))
+	})
+	t.Run("Search", func(t *testing.T) {
+		testSearch(t, repo(t))
+	})
+	t.Run("History", func(t *testing.T) {
+		testHistory(t, repo(t))
+	})
+	t.Run("Archive", func(t *testing.T) {
+		testArchive(t, repo(t))
+	})
+}
+
+func testListFilters(t *testing.T, repo db.EventsRepo) {
+	for _, tc := range []struct {
+		name   string
+		filter *sports.ListEventsRequestFilter
+		want   []int64
+	}{
+		{"none", nil, []int64{3, 1, 4, 2, 5}},
+		{"meeting ids", &sports.ListEventsRequestFilter{MeetingIds: []int64{1, 3}}, []int64{1, 2, 5}},
+		{"visible only", &sports.ListEventsRequestFilter{VisibleOnly: true}, []int64{1, 4, 2, 5}},
+		{"combined", &sports.ListEventsRequestFilter{MeetingIds: []int64{2}, VisibleOnly: true}, []int64{4}},
+		{"no matches", &sports.ListEventsRequestFilter{MeetingIds: []int64{99}}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			events, token, err := repo.List(&sports.ListEventsRequest{Filter: tc.filter})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if token != "" {
+				t.Errorf("next page token = %q, want none", token)
+			}
+			checkIds(t, eventIds(events), tc.want)
+		})
+	}
+
+	for _, tc := range []struct {
+		name  string
+		req   *sports.ListEventsRequest
+		field string
+	}{
+		{"unknown sort", &sports.ListEventsRequest{Filter: &sports.ListEventsRequestFilter{SortBy: "sport"}}, "filter.sort_by"},
+		{"sort by a column not allowed", &sports.ListEventsRequest{Filter: &sports.ListEventsRequestFilter{SortBy: "sold_out"}}, "filter.sort_by"},
+		{"negative page size", &sports.ListEventsRequest{PageSize: -1}, "page_size"},
+		{"malformed page token", &sports.ListEventsRequest{PageToken: "!"}, "page_token"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := repo.List(tc.req)
+			checkFieldError(t, err, tc.field)
+		})
+	}
+}
+
+func testListOrdering(t *testing.T, repo db.EventsRepo) {
+	for _, tc := range []struct {
+		name   string
+		filter *sports.ListEventsRequestFilter
+		want   []int64
+	}{
+		{"start ascending", &sports.ListEventsRequestFilter{Order: "asc"}, []int64{5, 2, 4, 1, 3}},
+		{"name", &sports.ListEventsRequestFilter{SortBy: "NAME", Order: "ascending"}, []int64{5, 4, 3, 1, 2}},
+		{"name descending", &sports.ListEventsRequestFilter{SortBy: "name"}, []int64{2, 1, 3, 4, 5}},
+		{"level", &sports.ListEventsRequestFilter{SortBy: "level", Order: "ASC"}, []int64{2, 4, 1, 5, 3}},
+		// Ties are broken by ID, always ascending.
+		{"number", &sports.ListEventsRequestFilter{SortBy: "number", Order: "ASC"}, []int64{1, 3, 2, 5, 4}},
+		{"number descending", &sports.ListEventsRequestFilter{SortBy: "number"}, []int64{4, 2, 5, 1, 3}},
+		{"meeting", &sports.ListEventsRequestFilter{SortBy: "meeting_id", Order: "ASC"}, []int64{1, 2, 3, 4, 5}},
+		{"id descending", &sports.ListEventsRequestFilter{SortBy: "id"}, []int64{5, 4, 3, 2, 1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			events, _, err := repo.List(&sports.ListEventsRequest{Filter: tc.filter})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			checkIds(t, eventIds(events), tc.want)
+		})
+	}
+}
+
+func testListPaging(t *testing.T, repo db.EventsRepo) {
+	for _, sortBy := range []string{"advertised_start_time", "number", "name", "level"} {
+		t.Run(sortBy, func(t *testing.T) {
+			filter := &sports.ListEventsRequestFilter{SortBy: sortBy}
+
+			all, _, err := repo.List(&sports.ListEventsRequest{Filter: filter})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+
+			var (
+				ids   []int64
+				pages int
+				req   = &sports.ListEventsRequest{Filter: filter, PageSize: 2}
+			)
+			for {
+				events, token, err := repo.List(req)
+				if err != nil {
+					t.Fatalf("List page %d: %v", pages, err)
+				}
+				ids = append(ids, eventIds(events)...)
+				pages++
+
+				if token == "" {
+					break
+				}
+				req.PageToken = token
+			}
+
+			checkIds(t, ids, eventIds(all))
+			if pages != 3 {
+				t.Errorf("pages = %d, want 3", pages)
+			}
+		})
+	}
+
+	// A token is only valid for the request it was issued for.
+	_, token, err := repo.List(&sports.ListEventsRequest{PageSize: 1})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	_, _, err = repo.List(&sports.ListEventsRequest{PageSize: 1, PageToken: token, Filter: &sports.ListEventsRequestFilter{VisibleOnly: true}})
+	checkFieldError(t, err, "page_token")
+}
+
+func testPageTokens(t *testing.T, repo db.EventsRepo) {
+	// Events 5 and 2 start first, so are on the first page.
+	req := &sports.ListEventsRequest{Filter: &sports.ListEventsRequestFilter{Order: "asc"}, PageSize: 2}
+
+	var ids []int64
+	for pages := 0; ; pages++ {
+		events, token, err := repo.List(req)
+		if err != nil {
+			t.Fatalf("List page %d: %v", pages, err)
+		}
+		ids = append(ids, eventIds(events)...)
+
+		// Archiving the events already listed leaves them out of the list,
+		// but pages resume after the last event listed, so none are
+		// skipped.
+		if pages == 0 {
+			if n, err := repo.Archive(30*time.Minute, 10, db.Audit{Actor: "retention"}); err != nil || n != 2 {
+				t.Fatalf("Archive = %d, %v, want 2 archived", n, err)
+			}
+		}
+
+		if token == "" {
+			break
+		}
+		req.PageToken = token
+	}
+	checkIds(t, ids, []int64{5, 2, 4, 1, 3})
+
+	// Events 1 and 3 tie on number 1, and are broken by ID.
+	byNumber := &sports.ListEventsRequestFilter{SortBy: "number", Order: "asc"}
+	events, token, err := repo.List(&sports.ListEventsRequest{Filter: byNumber, PageSize: 1})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	checkIds(t, eventIds(events), []int64{1})
+
+	events, _, err = repo.List(&sports.ListEventsRequest{Filter: byNumber, PageSize: 1, PageToken: token})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	checkIds(t, eventIds(events), []int64{3})
+
+	for _, tc := range []struct {
+		name string
+		req  *sports.ListEventsRequest
+	}{
+		{"different filter", &sports.ListEventsRequest{Filter: &sports.ListEventsRequestFilter{SortBy: "number", Order: "asc", VisibleOnly: true}, PageToken: token}},
+		{"different sort", &sports.ListEventsRequest{Filter: &sports.ListEventsRequestFilter{SortBy: "name", Order: "asc"}, PageToken: token}},
+		{"different order", &sports.ListEventsRequest{Filter: &sports.ListEventsRequestFilter{SortBy: "number"}, PageToken: token}},
+		{"tampered checksum", &sports.ListEventsRequest{Filter: byNumber, PageToken: tamperToken(t, token, func(token map[string]interface{}) {
+			token["c"] = token["c"].(float64) + 1
+		})}},
+		{"missing key", &sports.ListEventsRequest{Filter: byNumber, PageToken: tamperToken(t, token, func(token map[string]interface{}) {
+			token["k"] = token["k"].([]interface{})[:1]
+		})}},
+		{"mistyped key", &sports.ListEventsRequest{Filter: byNumber, PageToken: tamperToken(t, token, func(token map[string]interface{}) {
+			token["k"].([]interface{})[0] = "one"
+		})}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := repo.List(tc.req)
+			checkFieldError(t, err, "page_token")
+		})
+	}
+}
+
+// tamperToken decodes a page token, changes it, and encodes it again, as a
+// caller forging a token might. Tokens are base64 encoded JSON, holding the
+// checksum of the request as "c" and the keys of the last event as "k".
+func tamperToken(t *testing.T, token string, tamper func(map[string]interface{})) string {
+	t.Helper()
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		t.Fatalf("decoding page token: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("decoding page token: %v", err)
+	}
+	tamper(decoded)
+
+	if b, err = json.Marshal(decoded); err != nil {
+		t.Fatalf("encoding page token: %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func testStatusDerivation(t *testing.T, repo db.EventsRepo) {
+	for _, tc := range []struct {
+		name string
+		asOf *timestamp.Timestamp
+		want map[int64]string
+	}{
+		{"now", nil, map[int64]string{1: "OPEN", 2: "CLOSED", 3: "OPEN", 4: "OPEN", 5: "CLOSED"}},
+		{"earlier", at(-2 * time.Hour), map[int64]string{1: "OPEN", 2: "OPEN", 3: "OPEN", 4: "OPEN", 5: "CLOSED"}},
+		// An event is open up to and including its start time.
+		{"at a start", at(-time.Hour), map[int64]string{1: "OPEN", 2: "OPEN", 3: "OPEN", 4: "OPEN", 5: "CLOSED"}},
+		// Instants are compared to the millisecond, as SQLite compares them.
+		{"within a millisecond of a start", at(-time.Hour + 999*time.Microsecond), map[int64]string{1: "OPEN", 2: "OPEN", 3: "OPEN", 4: "OPEN", 5: "CLOSED"}},
+		{"later", at(90 * time.Minute), map[int64]string{1: "CLOSED", 2: "CLOSED", 3: "OPEN", 4: "CLOSED", 5: "CLOSED"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			events, _, err := repo.List(&sports.ListEventsRequest{AsOf: tc.asOf})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+
+			for _, event := range events {
+				if event.Status != tc.want[event.Id] {
+					t.Errorf("event %d status = %s, want %s", event.Id, event.Status, tc.want[event.Id])
+				}
+			}
+		})
+	}
+}
+
+func testReadMask(t *testing.T, repo db.EventsRepo) {
+	events, token, err := repo.List(&sports.ListEventsRequest{
+		Filter:   &sports.ListEventsRequestFilter{SortBy: "level"},
+		ReadMask: &field_mask.FieldMask{Paths: []string{"name"}},
+		PageSize: 1,
+	})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	// Fields ordered on are read along with those masked, to page on.
+	event := events[0]
+	if event.Name != "Soccer Cup" || event.Id != 3 || event.Level != "Youth" {
+		t.Errorf("event = %v, want its name, ID and level", event)
+	}
+	if event.MeetingId != 0 || event.AdvertisedStartTime != nil || event.Status != "" || event.Etag != "" {
+		t.Errorf("event = %v, want no other fields", event)
+	}
+
+	if _, _, err := repo.List(&sports.ListEventsRequest{
+		Filter:    &sports.ListEventsRequestFilter{SortBy: "level"},
+		ReadMask:  &field_mask.FieldMask{Paths: []string{"name"}},
+		PageToken: token,
+	}); err != nil {
+		t.Errorf("List next page: %v", err)
+	}
+
+	// Status is derived from the start time, so is read along with it.
+	events, _, err = repo.List(&sports.ListEventsRequest{
+		Filter:   &sports.ListEventsRequestFilter{SortBy: "id", Order: "ASC"},
+		ReadMask: &field_mask.FieldMask{Paths: []string{"status", "sold_out", "etag"}},
+	})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	event = events[3]
+	if event.Status != "OPEN" || event.AdvertisedStartTime == nil || !event.SoldOut || event.Etag != "1" || event.Name != "" {
+		t.Errorf("event = %v, want its status, start time, sold out and etag", event)
+	}
+
+	_, _, err = repo.List(&sports.ListEventsRequest{ReadMask: &field_mask.FieldMask{Paths: []string{"teams"}}})
+	checkFieldError(t, err, "read_mask")
+}
+
+func testSearch(t *testing.T, repo db.EventsRepo) {
+	results, _, err := repo.Search(&sports.SearchEventsRequest{Query: "cup"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	// Shorter names, in which a match counts for more, are more relevant,
+	// and equally relevant events are ordered by ID.
+	var ids []int64
+	for i, result := range results {
+		ids = append(ids, result.Event.Id)
+
+		if !strings.Contains(result.Snippet, "<mark>Cup</mark>") {
+			t.Errorf("snippet = %q, want Cup marked", result.Snippet)
+		}
+		if i > 0 && result.Score > results[i-1].Score {
+			t.Errorf("result %d scores %v, more than the result before it", i, result.Score)
+		}
+	}
+	checkIds(t, ids, []int64{3, 4})
+
+	results, _, err = repo.Search(&sports.SearchEventsRequest{Query: "final"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 2 || results[0].Event.Id != 1 || results[1].Event.Id != 4 || results[0].Score != results[1].Score {
+		t.Errorf("results = %v, want events 1 and 4, scored alike", results)
+	}
+
+	// Words match the start of words in names, ignoring case and diacritics,
+	// and the filter restricts matches.
+	results, _, err = repo.Search(&sports.SearchEventsRequest{Query: "fin TÉNN", Filter: &sports.ListEventsRequestFilter{VisibleOnly: true}})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Snippet != "<mark>Tennis</mark> Open <mark>Final</mark>" || results[0].Event.Status != "OPEN" {
+		t.Errorf("results = %v, want open event 1 with both words marked", results)
+	}
+
+	results, _, err = repo.Search(&sports.SearchEventsRequest{Query: "cup", Filter: &sports.ListEventsRequestFilter{VisibleOnly: true}})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Event.Id != 4 {
+		t.Errorf("results = %v, want only event 4", results)
+	}
+
+	var paged []int64
+	req := &sports.SearchEventsRequest{Query: "cup", PageSize: 1}
+	for {
+		results, token, err := repo.Search(req)
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		for _, result := range results {
+			paged = append(paged, result.Event.Id)
+		}
+
+		if token == "" {
+			break
+		}
+		req.PageToken = token
+	}
+	checkIds(t, paged, ids)
+
+	// A token is only valid for the search it was issued for, as its score
+	// only orders the matches of the same query.
+	_, token, err := repo.Search(&sports.SearchEventsRequest{Query: "cup", PageSize: 1})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	for _, tc := range []struct {
+		name string
+		req  *sports.SearchEventsRequest
+	}{
+		{"different query", &sports.SearchEventsRequest{Query: "final", PageSize: 1, PageToken: token}},
+		{"different filter", &sports.SearchEventsRequest{Query: "cup", PageSize: 1, PageToken: token, Filter: &sports.ListEventsRequestFilter{VisibleOnly: true}}},
+		{"tampered checksum", &sports.SearchEventsRequest{Query: "cup", PageSize: 1, PageToken: tamperToken(t, token, func(token map[string]interface{}) {
+			token["c"] = token["c"].(float64) + 1
+		})}},
+		{"missing key", &sports.SearchEventsRequest{Query: "cup", PageSize: 1, PageToken: tamperToken(t, token, func(token map[string]interface{}) {
+			token["k"] = token["k"].([]interface{})[:1]
+		})}},
+		{"mistyped key", &sports.SearchEventsRequest{Query: "cup", PageSize: 1, PageToken: tamperToken(t, token, func(token map[string]interface{}) {
+			token["k"].([]interface{})[0] = "one"
+		})}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := repo.Search(tc.req)
+			checkFieldError(t, err, "page_token")
+		})
+	}
+
+	_, _, err = repo.Search(&sports.SearchEventsRequest{Query: " ?! "})
+	checkFieldError(t, err, "query")
+}
+
+func testHistory(t *testing.T, repo db.EventsRepo) {
+	history := EventsFixture().History
+
+	for _, tc := range []struct {
+		name    string
+		eventId int64
+		want    []*sports.EventChange
+	}{
+		{"seeded", 2, history[:1]},
+		{"deleted", 6, []*sports.EventChange{history[1], history[2]}},
+		{"no changes", 3, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			changes, token, err := repo.History(&sports.ListEventHistoryRequest{EventId: tc.eventId})
+			if err != nil {
+				t.Fatalf("History: %v", err)
+			}
+			if token != "" {
+				t.Errorf("next page token = %q, want none", token)
+			}
+			checkChanges(t, changes, tc.want)
+		})
+	}
+
+	var paged []*sports.EventChange
+	req := &sports.ListEventHistoryRequest{EventId: 6, PageSize: 1}
+	for {
+		changes, token, err := repo.History(req)
+		if err != nil {
+			t.Fatalf("History: %v", err)
+		}
+		paged = append(paged, changes...)
+
+		if token == "" {
+			break
+		}
+		req.PageToken = token
+	}
+	checkChanges(t, paged, []*sports.EventChange{history[1], history[2]})
+
+	// A page token is only valid for the event it was issued for.
+	_, token, err := repo.History(&sports.ListEventHistoryRequest{EventId: 6, PageSize: 1})
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	_, _, err = repo.History(&sports.ListEventHistoryRequest{EventId: 2, PageToken: token})
+	checkFieldError(t, err, "page_token")
+
+	_, _, err = repo.History(&sports.ListEventHistoryRequest{})
+	checkFieldError(t, err, "event_id")
+}
+
+func testArchive(t *testing.T, repo db.EventsRepo) {
+	audit := db.Audit{Actor: "retention", Reason: "started more than 2h0m0s ago"}
+
+	// Only event 5 started more than two hours ago.
+	n, err := repo.Archive(2*time.Hour, 10, audit)
+	if err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("archived %d events, want 1", n)
+	}
+
+	events, _, err := repo.List(&sports.ListEventsRequest{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	checkIds(t, eventIds(events), []int64{3, 1, 4, 2})
+
+	events, _, err = repo.List(&sports.ListEventsRequest{Filter: &sports.ListEventsRequestFilter{IncludeArchived: true, MeetingIds: []int64{3}}})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	checkIds(t, eventIds(events), []int64{5})
+	if len(events) == 1 && !proto.Equal(events[0].ArchivedTime, at(0)) {
+		t.Errorf("event 5 archived time = %v, want %v", events[0].ArchivedTime, at(0))
+	}
+
+	results, _, err := repo.Search(&sports.SearchEventsRequest{Query: "archery"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("search results = %v, want none", results)
+	}
+
+	changes, _, err := repo.History(&sports.ListEventHistoryRequest{EventId: 5})
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	checkChanges(t, changes, []*sports.EventChange{
+		{Id: 5, EventId: 5, Action: sports.EventChange_UPDATED, Actor: audit.Actor, Reason: audit.Reason, ChangeTime: at(0), Etag: "2", Fields: []*sports.FieldChange{
+			{Field: "archived_time", NewValue: Now.Format(time.RFC3339Nano)},
+		}},
+	})
+
+	// Event 5 is archived only once, so only event 2 is archived now.
+	n, err = repo.Archive(30*time.Minute, 1, audit)
+	if err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("archived %d events, want 1", n)
+	}
+
+	events, _, err = repo.List(&sports.ListEventsRequest{Filter: &sports.ListEventsRequestFilter{MeetingIds: []int64{1}}})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	checkIds(t, eventIds(events), []int64{1})
+
+	if _, err := repo.Archive(time.Hour, 0, audit); !errors.Is(err, db.ErrInvalidArgument) {
+		t.Errorf("Archive with no limit: err = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func checkChanges(t *testing.T, got, want []*sports.EventChange) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("changes = %v, want %v", got, want)
+	}
+
+	for i := range got {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("change %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+// at returns the instant d after Now.
+func at(d time.Duration) *timestamp.Timestamp {
+	ts, _ := ptypes.TimestampProto(Now.Add(d))
+	return ts
+}
+
+func eventIds(events []*sports.Event) []int64 {
+	ids := []int64{}
+	for _, event := range events {
+		ids = append(ids, event.Id)
+	}
+
+	return ids
+}
+
+func checkIds(t *testing.T, got, want []int64) {
+	t.Helper()
+
+	if len(got) == 0 && len(want) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ids = %v, want %v", got, want)
+	}
+}
+
+func checkFieldError(t *testing.T, err error, field string) {
+	t.Helper()
+
+	var fieldErr *db.FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != field {
+		t.Errorf("err = %v, want a field error for %s", err, field)
+	}
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidArgument is wrapped by errors caused by a caller supplying a
+	// filter, ordering or other request value the repository cannot honour.
+	ErrInvalidArgument = errors.New("invalid argument")
+
+	// ErrNotFound is wrapped by errors caused by a requested resource not
+	// existing.
+	ErrNotFound = errors.New("not found")
+
+	// ErrFailedPrecondition is wrapped by errors caused by the database not
+	// being in a state that permits the requested change.
+	ErrFailedPrecondition = errors.New("failed precondition")
+)
+
+// FieldError is an ErrInvalidArgument caused by the value of a single field
+// of a request.
+type FieldError struct {
+	// Field is the path of the field within the request, such as
+	// filter.sort_by.
+	Field string
+	// Description says what is wrong with the field's value.
+	Description string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrInvalidArgument, e.Field, e.Description)
+}
+
+// Unwrap makes a FieldError match ErrInvalidArgument.
+func (e *FieldError) Unwrap() error {
+	return ErrInvalidArgument
+}
+
+// invalidField returns a FieldError for field, its description formatted
+// from format and args.
+func invalidField(field, format string, args ...interface{}) error {
+	return &FieldError{Field: field, Description: fmt.Sprintf(format, args...)}
+}
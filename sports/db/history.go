@@ -0,0 +1,242 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"sports/proto/sports"
+
+	"git.neds.sh/matty/entain/shared/page"
+)
+
+// Audit says who is making a change and why, to be recorded in the history
+// of each event it changes.
+type Audit struct {
+	// Actor names who is making the change, such as a user or a command.
+	Actor string
+	// Reason says why the change is being made, and may be empty.
+	Reason string
+}
+
+// eventHistoryFields are the stored fields of an event recorded in its
+// history, in the order they are declared. Status is derived, so is never
+// changed by a write.
+var eventHistoryFields = []string{"meeting_id", "name", "number", "visible", "advertised_start_time", "level", "sold_out", "archived_time"}
+
+// fieldChange is the stored form of a sports.FieldChange.
+type fieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// eventFieldValues renders the recorded fields of an event as they are
+// written in JSON, unquoted. A nil event has no values.
+func eventFieldValues(event *sports.Event) map[string]string {
+	if event == nil {
+		return nil
+	}
+
+	values := map[string]string{
+		"meeting_id": strconv.FormatInt(event.MeetingId, 10),
+		"name":       event.Name,
+		"number":     strconv.FormatInt(event.Number, 10),
+		"visible":    strconv.FormatBool(event.Visible),
+		"level":      event.Level,
+		"sold_out":   strconv.FormatBool(event.SoldOut),
+	}
+	if event.AdvertisedStartTime != nil {
+		values["advertised_start_time"] = event.AdvertisedStartTime.AsTime().UTC().Format(time.RFC3339Nano)
+	}
+	if event.ArchivedTime != nil {
+		values["archived_time"] = event.ArchivedTime.AsTime().UTC().Format(time.RFC3339Nano)
+	}
+
+	return values
+}
+
+// newEventChange describes a change to an event from before to after, either
+// of which is nil where the event does not exist, made at the given time.
+// Only the fields whose values differ are recorded.
+func newEventChange(before, after *sports.Event, audit Audit, at time.Time) (*sports.EventChange, error) {
+	changeTime, err := ptypes.TimestampProto(at)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &sports.EventChange{
+		Action:     sports.EventChange_UPDATED,
+		Actor:      audit.Actor,
+		Reason:     audit.Reason,
+		ChangeTime: changeTime,
+	}
+
+	switch {
+	case before == nil:
+		change.Action = sports.EventChange_CREATED
+		change.EventId, change.Etag = after.Id, after.Etag
+	case after == nil:
+		change.Action = sports.EventChange_DELETED
+		change.EventId, change.Etag = before.Id, before.Etag
+	default:
+		change.EventId, change.Etag = after.Id, after.Etag
+	}
+
+	old, new := eventFieldValues(before), eventFieldValues(after)
+	for _, field := range eventHistoryFields {
+		if old[field] != new[field] {
+			change.Fields = append(change.Fields, &sports.FieldChange{Field: field, OldValue: old[field], NewValue: new[field]})
+		}
+	}
+
+	return change, nil
+}
+
+// eventChangeArgs returns the values of a change to insert with
+// eventsHistoryInsert.
+func eventChangeArgs(change *sports.EventChange) ([]interface{}, error) {
+	fields := make([]fieldChange, 0, len(change.Fields))
+	for _, field := range change.Fields {
+		fields = append(fields, fieldChange{Field: field.Field, Old: field.OldValue, New: field.NewValue})
+	}
+
+	changes, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := strconv.ParseInt(change.Etag, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{
+		change.EventId,
+		change.Action.String(),
+		change.Actor,
+		change.Reason,
+		change.ChangeTime.AsTime().UTC().Format(time.RFC3339Nano),
+		version,
+		string(changes),
+	}, nil
+}
+
+// recordEventChange appends the change from before to after to the event's
+// history, within the transaction making it.
+func recordEventChange(tx *sql.Tx, before, after *sports.Event, audit Audit, at time.Time) error {
+	change, err := newEventChange(before, after, audit, at)
+	if err != nil {
+		return err
+	}
+
+	args, err := eventChangeArgs(change)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(getEventQueries()[eventsHistoryInsert], args...)
+	return err
+}
+
+// historyPage validates a request for an event's history, returning the page
+// size, the checksum page tokens are made with, and the ID of the last change
+// on the previous page, or 0 for the first page.
+func historyPage(in *sports.ListEventHistoryRequest) (int, uint32, int64, error) {
+	if in.GetEventId() < 1 {
+		return 0, 0, 0, invalidField("event_id", "must be positive")
+	}
+
+	size, err := pageSize(in.GetPageSize())
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	// Changes are always ordered by ID, so a page token records the ID of
+	// the last change returned.
+	checksum := pageChecksum(&sports.ListEventHistoryRequest{EventId: in.GetEventId()}, nil)
+	if in.GetPageToken() == "" {
+		return size, checksum, 0, nil
+	}
+
+	keys, err := decodePageToken(in.GetPageToken(), checksum)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	var after int64
+	if err := page.DecodeKeys(keys, &after); err != nil {
+		return 0, 0, 0, invalidPageToken(err)
+	}
+
+	return size, checksum, after, nil
+}
+
+// historyNextPage trims changes, which hold one more than a page if there is
+// a next page, to size, returning the token for the next page.
+func historyNextPage(changes []*sports.EventChange, size int, checksum uint32) ([]*sports.EventChange, string, error) {
+	if len(changes) <= size {
+		return changes, "", nil
+	}
+
+	changes = changes[:size]
+	token, err := page.EncodeToken(checksum, []interface{}{changes[size-1].Id})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return changes, token, nil
+}
+
+func (r *eventsRepo) History(in *sports.ListEventHistoryRequest) ([]*sports.EventChange, string, error) {
+	size, checksum, after, err := historyPage(in)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Fetch one more change than requested to learn whether a next page
+	// exists.
+	rows, err := r.db.Query(getEventQueries()[eventsHistory], in.EventId, after, size+1)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var changes []*sports.EventChange
+	for rows.Next() {
+		var (
+			change     sports.EventChange
+			action     string
+			changeTime time.Time
+			version    int64
+			stored     string
+		)
+		if err := rows.Scan(&change.Id, &change.EventId, &action, &change.Actor, &change.Reason, &changeTime, &version, &stored); err != nil {
+			return nil, "", err
+		}
+
+		change.Action = sports.EventChange_Action(sports.EventChange_Action_value[action])
+		change.Etag = strconv.FormatInt(version, 10)
+		if change.ChangeTime, err = ptypes.TimestampProto(changeTime); err != nil {
+			return nil, "", err
+		}
+
+		var fields []fieldChange
+		if err := json.Unmarshal([]byte(stored), &fields); err != nil {
+			return nil, "", err
+		}
+		for _, field := range fields {
+			change.Fields = append(change.Fields, &sports.FieldChange{Field: field.Field, OldValue: field.Old, NewValue: field.New})
+		}
+
+		changes = append(changes, &change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	return historyNextPage(changes, size, checksum)
+}
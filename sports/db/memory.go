@@ -0,0 +1,439 @@
+package db
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/protobuf/proto"
+
+	"sports/proto/sports"
+
+	"git.neds.sh/matty/entain/shared/page"
+	"git.neds.sh/matty/entain/shared/search"
+)
+
+// EventsFixture is the data an in-memory events repository is created with.
+type EventsFixture struct {
+	// Events are stored as given, except that their status is always
+	// derived, and one without an etag is at its first version.
+	Events []*sports.Event
+	// History holds the changes recorded to events, in ID order.
+	History []*sports.EventChange
+}
+
+// memoryEvent is an event as the in-memory repository stores it, mirroring
+// a row of the events table.
+type memoryEvent struct {
+	id              int64
+	meetingId       int64
+	name            string
+	number          int64
+	visible         bool
+	advertisedStart time.Time
+	level           string
+	soldOut         bool
+	version         int64
+	// archived is when the event was archived, or zero if it is not.
+	archived time.Time
+}
+
+type memoryEventsRepo struct {
+	clock Clock
+
+	mu      sync.Mutex
+	events  map[int64]*memoryEvent
+	history []*sports.EventChange
+}
+
+// NewMemoryEventsRepo creates an events repository holding fixture in
+// memory, deriving event status from clock. It behaves as the SQLite
+// repository does, so that it can stand in for it in tests.
+func NewMemoryEventsRepo(clock Clock, fixture EventsFixture) EventsRepo {
+	r := &memoryEventsRepo{
+		clock:  clock,
+		events: make(map[int64]*memoryEvent, len(fixture.Events)),
+	}
+
+	for _, event := range fixture.Events {
+		row := &memoryEvent{
+			id:        event.Id,
+			meetingId: event.MeetingId,
+			name:      event.Name,
+			number:    event.Number,
+			visible:   event.Visible,
+			level:     event.Level,
+			soldOut:   event.SoldOut,
+			version:   1,
+		}
+		if event.AdvertisedStartTime != nil {
+			row.advertisedStart = event.AdvertisedStartTime.AsTime()
+		}
+		if event.Etag != "" {
+			row.version, _ = strconv.ParseInt(event.Etag, 10, 64)
+		}
+		if event.ArchivedTime != nil {
+			row.archived = event.ArchivedTime.AsTime()
+		}
+
+		r.events[row.id] = row
+	}
+
+	for _, change := range fixture.History {
+		r.history = append(r.history, proto.Clone(change).(*sports.EventChange))
+	}
+
+	return r
+}
+
+// Init does nothing, as the repository holds only its fixture.
+func (r *memoryEventsRepo) Init() error {
+	return nil
+}
+
+func (r *memoryEventsRepo) List(in *sports.ListEventsRequest) ([]*sports.Event, string, error) {
+	terms, err := eventOrderTerms(in.GetFilter())
+	if err != nil {
+		return nil, "", err
+	}
+
+	size, err := pageSize(in.GetPageSize())
+	if err != nil {
+		return nil, "", err
+	}
+
+	now, err := statusInstant(r.clock, in.GetAsOf())
+	if err != nil {
+		return nil, "", err
+	}
+
+	match := filterEvents(in.GetFilter())
+
+	columns, err := readColumns(in.GetReadMask(), terms)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Resume after the last event of the previous page, if there was one.
+	var after []interface{}
+	checksum := pageChecksum(in.GetFilter(), terms)
+	if in.GetPageToken() != "" {
+		keys, err := decodePageToken(in.GetPageToken(), checksum)
+		if err != nil {
+			return nil, "", err
+		}
+
+		after, err = decodeKeys(terms, keys)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var rows []*memoryEvent
+	for _, row := range r.events {
+		if match(row) && (after == nil || row.sortsAfter(terms, after)) {
+			rows = append(rows, row)
+		}
+	}
+	sortEvents(rows, terms)
+
+	var (
+		events        []*sports.Event
+		nextPageToken string
+	)
+	for _, row := range rows {
+		if len(events) == size {
+			nextPageToken, err = page.EncodeToken(checksum, orderKeys(terms, events[size-1]))
+			if err != nil {
+				return nil, "", err
+			}
+			break
+		}
+
+		events = append(events, row.event(now, columns))
+	}
+
+	return events, nextPageToken, nil
+}
+
+func (r *memoryEventsRepo) Search(in *sports.SearchEventsRequest) ([]*sports.EventSearchResult, string, error) {
+	words := search.Words(in.GetQuery())
+	if len(words) == 0 {
+		return nil, "", invalidField("query", "must contain a word to search for")
+	}
+
+	size, err := pageSize(in.GetPageSize())
+	if err != nil {
+		return nil, "", err
+	}
+
+	now, err := statusInstant(r.clock, in.GetAsOf())
+	if err != nil {
+		return nil, "", err
+	}
+
+	match := filterEvents(in.GetFilter())
+
+	// Results are always ordered by score then ID, so a page token records
+	// the score and ID of the last result returned.
+	var (
+		afterScore float64
+		afterId    int64
+		paged      bool
+	)
+	checksum := pageChecksum(&sports.SearchEventsRequest{Query: in.GetQuery(), Filter: in.GetFilter()}, nil)
+	if in.GetPageToken() != "" {
+		keys, err := decodePageToken(in.GetPageToken(), checksum)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if err := page.DecodeKeys(keys, &afterScore, &afterId); err != nil {
+			return nil, "", invalidPageToken(err)
+		}
+		paged = true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Every event is indexed, whether or not it matches the filter.
+	names := make(map[int64]string, len(r.events))
+	for id, row := range r.events {
+		names[id] = row.name
+	}
+	hits := search.Match(names, words)
+
+	var ids []int64
+	for id, hit := range hits {
+		if !match(r.events[id]) {
+			continue
+		}
+		if paged && !(hit.Score > afterScore || (hit.Score == afterScore && id > afterId)) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := hits[ids[i]], hits[ids[j]]
+		if a.Score != b.Score {
+			return a.Score < b.Score
+		}
+		return ids[i] < ids[j]
+	})
+
+	var (
+		results       []*sports.EventSearchResult
+		nextPageToken string
+	)
+	for _, id := range ids {
+		if len(results) == size {
+			last := ids[size-1]
+			nextPageToken, err = page.EncodeToken(checksum, []interface{}{hits[last].Score, last})
+			if err != nil {
+				return nil, "", err
+			}
+			break
+		}
+
+		// Scores are negated for callers, so that higher is more relevant.
+		hit := hits[id]
+		results = append(results, &sports.EventSearchResult{
+			Event:   r.events[id].event(now, eventColumns),
+			Snippet: hit.Snippet,
+			Score:   -hit.Score,
+		})
+	}
+
+	return results, nextPageToken, nil
+}
+
+func (r *memoryEventsRepo) Archive(olderThan time.Duration, limit int, audit Audit) (int64, error) {
+	if err := validateArchive(olderThan, limit); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.clock.Now()
+	cutoff := instantMillis(now.Add(-olderThan))
+
+	var past []*memoryEvent
+	for _, row := range r.events {
+		if row.archived.IsZero() && !row.advertisedStart.IsZero() && instantMillis(row.advertisedStart) < cutoff {
+			past = append(past, row)
+		}
+	}
+	sortEvents(past, []orderTerm{
+		{eventOrderField: eventOrderFields["advertised_start_time"]},
+		{eventOrderField: eventOrderFields["id"]},
+	})
+	if len(past) > limit {
+		past = past[:limit]
+	}
+
+	for _, row := range past {
+		event := row.event(now, eventColumns)
+
+		row.archived = now
+		row.version++
+
+		if err := r.record(event, row.event(now, eventColumns), audit); err != nil {
+			return 0, err
+		}
+	}
+
+	return int64(len(past)), nil
+}
+
+// filterEvents resolves a filter, as applyFilter does, to a function
+// reporting whether an event matches it.
+func filterEvents(filter *sports.ListEventsRequestFilter) func(*memoryEvent) bool {
+	return func(row *memoryEvent) bool {
+		if !filter.GetIncludeArchived() && !row.archived.IsZero() {
+			return false
+		}
+
+		if filter == nil {
+			return true
+		}
+
+		if len(filter.MeetingIds) > 0 {
+			found := false
+			for _, meetingId := range filter.MeetingIds {
+				found = found || row.meetingId == meetingId
+			}
+			if !found {
+				return false
+			}
+		}
+
+		return !filter.VisibleOnly || row.visible
+	}
+}
+
+// event converts a stored event to an event as read from the given
+// columns, as scanEvent does. Fields not read from any of the columns are
+// left unset.
+func (m *memoryEvent) event(now time.Time, columns []string) *sports.Event {
+	var event sports.Event
+
+	for _, column := range columns {
+		switch column {
+		case "id":
+			event.Id = m.id
+		case "meeting_id":
+			event.MeetingId = m.meetingId
+		case "name":
+			event.Name = m.name
+		case "number":
+			event.Number = m.number
+		case "visible":
+			event.Visible = m.visible
+		case "advertised_start_time":
+			if m.advertisedStart.IsZero() {
+				continue
+			}
+
+			event.AdvertisedStartTime, _ = ptypes.TimestampProto(m.advertisedStart)
+			if now.After(m.advertisedStart) {
+				event.Status = "CLOSED"
+			} else {
+				event.Status = "OPEN"
+			}
+		case "level":
+			event.Level = m.level
+		case "sold_out":
+			event.SoldOut = m.soldOut
+		case "version":
+			event.Etag = strconv.FormatInt(m.version, 10)
+		case "archived_time":
+			if !m.archived.IsZero() {
+				event.ArchivedTime, _ = ptypes.TimestampProto(m.archived)
+			}
+		}
+	}
+
+	return &event
+}
+
+// orderKey returns the value of an event that the field is ordered on,
+// being an int64 or a string.
+func (m *memoryEvent) orderKey(field string) interface{} {
+	switch field {
+	case "advertised_start_time":
+		return instantMillis(m.advertisedStart)
+	case "meeting_id":
+		return m.meetingId
+	case "number":
+		return m.number
+	case "name":
+		return m.name
+	case "level":
+		return m.level
+	default:
+		return m.id
+	}
+}
+
+// sortsAfter reports whether an event sorts strictly after the position
+// recorded by the decoded page token values, as keysetClause matches.
+func (m *memoryEvent) sortsAfter(terms []orderTerm, values []interface{}) bool {
+	for i, term := range terms {
+		value := values[i]
+		if term.field == "advertised_start_time" {
+			t, _ := time.Parse(time.RFC3339Nano, value.(string))
+			value = instantMillis(t)
+		}
+
+		if c := compareKeys(m.orderKey(term.field), value); c != 0 {
+			return (c > 0) != term.desc
+		}
+	}
+
+	return false
+}
+
+// sortEvents sorts events by terms, as orderClause does.
+func sortEvents(rows []*memoryEvent, terms []orderTerm) {
+	sort.Slice(rows, func(i, j int) bool {
+		for _, term := range terms {
+			if c := compareKeys(rows[i].orderKey(term.field), rows[j].orderKey(term.field)); c != 0 {
+				return (c < 0) != term.desc
+			}
+		}
+		return false
+	})
+}
+
+// compareKeys compares two order keys of the same type, returning -1, 0 or
+// 1. Strings compare by their bytes, as SQLite's default collation does.
+func compareKeys(a, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		b := b.(int64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	case string:
+		return strings.Compare(a, b.(string))
+	}
+
+	return 0
+}
+
+// instantMillis returns the millisecond an instant falls in, the precision
+// SQLite compares times at.
+func instantMillis(t time.Time) int64 {
+	return t.Unix()*1000 + int64(t.Nanosecond())/int64(time.Millisecond)
+}
@@ -0,0 +1,46 @@
+package db
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"sports/proto/sports"
+)
+
+func (r *memoryEventsRepo) History(in *sports.ListEventHistoryRequest) ([]*sports.EventChange, string, error) {
+	size, checksum, after, err := historyPage(in)
+	if err != nil {
+		return nil, "", err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var changes []*sports.EventChange
+	for _, change := range r.history {
+		if change.EventId == in.EventId && change.Id > after {
+			changes = append(changes, proto.Clone(change).(*sports.EventChange))
+		}
+		if len(changes) > size {
+			break
+		}
+	}
+
+	return historyNextPage(changes, size, checksum)
+}
+
+// record appends the change from before to after to the history held in
+// memory, assigning its ID as SQLite assigns row IDs, one past the last.
+func (r *memoryEventsRepo) record(before, after *sports.Event, audit Audit) error {
+	change, err := newEventChange(before, after, audit, r.clock.Now())
+	if err != nil {
+		return err
+	}
+
+	change.Id = 1
+	if len(r.history) > 0 {
+		change.Id = r.history[len(r.history)-1].Id + 1
+	}
+	r.history = append(r.history, change)
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package db
+
+import (
+	"database/sql"
+
+	"git.neds.sh/matty/entain/shared/migrate"
+)
+
+// ErrSchemaTooNew is returned when a database has been migrated by a newer
+// build, to a schema this build does not know.
+var ErrSchemaTooNew = migrate.ErrSchemaTooNew
+
+// MigrationStatus describes a migration and whether it has been applied.
+type MigrationStatus = migrate.Status
+
+// Migrate applies each pending migration in order, returning those applied.
+// Each migration is applied in its own transaction, along with the record of
+// it having been applied. A database already migrated beyond the newest
+// migration known is left untouched, and ErrSchemaTooNew returned.
+func Migrate(db *sql.DB) ([]MigrationStatus, error) {
+	return migrate.Migrate(db, migrations)
+}
+
+// SchemaStatus reports each known migration in order, followed by any applied
+// migrations that are not known, without changing the database.
+func SchemaStatus(db *sql.DB) ([]MigrationStatus, error) {
+	return migrate.SchemaStatus(db, migrations)
+}
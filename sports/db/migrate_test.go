@@ -0,0 +1,110 @@
+package db_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"sports/db"
+)
+
+func TestMigrate(t *testing.T) {
+	sportingDB := emptyDB(t)
+
+	applied, err := migrate(t, sportingDB)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if len(applied) == 0 {
+		t.Fatal("Migrate of an empty database applied nothing")
+	}
+
+	// Every migration is applied, in order.
+	statuses, err := db.SchemaStatus(sportingDB)
+	if err != nil {
+		t.Fatalf("SchemaStatus: %v", err)
+	}
+	if len(statuses) != len(applied) {
+		t.Fatalf("statuses = %+v, want the %d migrations applied", statuses, len(applied))
+	}
+	for i, status := range statuses {
+		if status.Version != applied[i].Version || status.Name != applied[i].Name || status.Applied.IsZero() || !status.Known {
+			t.Errorf("status %d = %+v, want %+v applied", i, status, applied[i])
+		}
+		if i > 0 && status.Version <= statuses[i-1].Version {
+			t.Errorf("migration %d applied after %d", status.Version, statuses[i-1].Version)
+		}
+	}
+
+	// Migrating again changes nothing.
+	again, err := migrate(t, sportingDB)
+	if err != nil {
+		t.Fatalf("Migrate again: %v", err)
+	}
+	if len(again) != 0 {
+		t.Errorf("Migrate again applied %+v, want nothing", again)
+	}
+
+	after, err := db.SchemaStatus(sportingDB)
+	if err != nil {
+		t.Fatalf("SchemaStatus: %v", err)
+	}
+	if !reflect.DeepEqual(after, statuses) {
+		t.Errorf("statuses = %+v after migrating again, want %+v", after, statuses)
+	}
+}
+
+func TestMigrateSchemaTooNew(t *testing.T) {
+	sportingDB, err := migratedDB(t)
+	if err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+
+	if _, err := sportingDB.Exec(`INSERT INTO schema_version (version, name, applied_time) VALUES (1000, 'from_a_newer_build', '2021-03-02T12:00:00Z')`); err != nil {
+		t.Fatalf("recording migration: %v", err)
+	}
+
+	if _, err := db.Migrate(sportingDB); !errors.Is(err, db.ErrSchemaTooNew) {
+		t.Errorf("Migrate: err = %v, want ErrSchemaTooNew", err)
+	}
+
+	// The unknown migration is reported last.
+	statuses, err := db.SchemaStatus(sportingDB)
+	if err != nil {
+		t.Fatalf("SchemaStatus: %v", err)
+	}
+	if last := statuses[len(statuses)-1]; last.Version != 1000 || last.Known {
+		t.Errorf("last status = %+v, want unknown migration 1000", last)
+	}
+}
+
+func TestMigrateUnversionedDatabase(t *testing.T) {
+	sportingDB := emptyDB(t)
+
+	// A database created before events were versioned, and before
+	// migrations were recorded.
+	for _, stmt := range []string{
+		`CREATE TABLE events (id INTEGER PRIMARY KEY, meeting_id INTEGER, name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME, level TEXT, sold_out INTEGER)`,
+		`INSERT INTO events (id, meeting_id, name, number, visible, advertised_start_time, level, sold_out) VALUES (1, 1, 'Grand Final', 1, 1, '2021-03-02T13:00:00Z', 'OPEN', 0)`,
+	} {
+		if _, err := sportingDB.Exec(stmt); err != nil {
+			t.Fatalf("creating unversioned database: %v", err)
+		}
+	}
+
+	if _, err := migrate(t, sportingDB); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	// The event is kept, at its first version.
+	var (
+		name    string
+		version int64
+	)
+	if err := sportingDB.QueryRow(`SELECT name, version FROM events WHERE id = 1`).Scan(&name, &version); err != nil {
+		t.Fatalf("querying event: %v", err)
+	}
+	if name != "Grand Final" || version != 1 {
+		t.Errorf("event = %q at version %d, want \"Grand Final\" at version 1", name, version)
+	}
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"database/sql"
+
+	"git.neds.sh/matty/entain/shared/migrate"
+	"git.neds.sh/matty/entain/shared/search"
+)
+
+// migrations are the changes to the schema of the events database, in the
+// order they are applied. A released migration is never changed; the schema
+// is changed by appending a new one.
+var migrations = []migrate.Migration{
+	{Version: 1, Name: "baseline", Up: migrateBaseline},
+	{Version: 2, Name: "events_start_time_and_meeting_indexes", Up: migrate.ExecAll(
+		// Events are filtered and ordered on their start time as an instant,
+		// so the index is on the same expression the queries use.
+		`CREATE INDEX IF NOT EXISTS events_advertised_start_time ON events (julianday(advertised_start_time))`,
+		`CREATE INDEX IF NOT EXISTS events_meeting_id ON events (meeting_id)`,
+	)},
+	{Version: 3, Name: "event_history", Up: migrate.ExecAll(
+		// Each change to an event is recorded with the fields it set, as a
+		// JSON array, and is never altered or removed, even along with the
+		// event.
+		`CREATE TABLE event_history (id INTEGER PRIMARY KEY, event_id INTEGER NOT NULL, action TEXT NOT NULL, actor TEXT NOT NULL, reason TEXT NOT NULL, changed_time DATETIME NOT NULL, version INTEGER NOT NULL, changes TEXT NOT NULL)`,
+		`CREATE INDEX event_history_event_id ON event_history (event_id, id)`,
+		`CREATE TRIGGER event_history_no_update BEFORE UPDATE ON event_history BEGIN SELECT RAISE(ABORT, 'event history is append-only'); END`,
+		`CREATE TRIGGER event_history_no_delete BEFORE DELETE ON event_history BEGIN SELECT RAISE(ABORT, 'event history is append-only'); END`,
+	)},
+	{Version: 4, Name: "events_archived_time", Up: migrate.ExecAll(
+		// Events long past are archived by retention rather than deleted.
+		// Archived events are only listed when asked for, so the start times
+		// of those not archived are indexed on their own.
+		`ALTER TABLE events ADD COLUMN archived_time DATETIME`,
+		`CREATE INDEX events_live_advertised_start_time ON events (julianday(advertised_start_time)) WHERE archived_time IS NULL`,
+	)},
+}
+
+// migrateBaseline creates the schema as it was before migrations were
+// introduced. Databases created since events were first stored may already
+// have some or all of it, created as the repository was initialised, so
+// every change is made only if it is missing.
+func migrateBaseline(tx *sql.Tx) error {
+	err := migrate.ExecAll(
+		`CREATE TABLE IF NOT EXISTS events (id INTEGER PRIMARY KEY, meeting_id INTEGER, name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME, level TEXT, sold_out INTEGER, version INTEGER NOT NULL DEFAULT 1)`,
+	)(tx)
+
+	// Databases created before events were versioned lack the column.
+	if err == nil {
+		err = migrate.AddColumnIfMissing(tx, "events", "version", `INTEGER NOT NULL DEFAULT 1`)
+	}
+
+	// Events are searched on name.
+	if err == nil {
+		err = search.CreateIndex(tx, "events")
+	}
+
+	return err
+}
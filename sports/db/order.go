@@ -0,0 +1,223 @@
+package db
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"sports/proto/sports"
+
+	"git.neds.sh/matty/entain/shared/page"
+)
+
+// eventOrderField describes how a single event field is ordered and paged on.
+type eventOrderField struct {
+	// field is the event field ordered on.
+	field string
+	// expr is the SQL expression the field is ordered by.
+	expr string
+	// param is the placeholder a page token value is compared through, so
+	// that it compares exactly like expr.
+	param string
+	// key extracts the value of the field recorded in a page token.
+	key func(*sports.Event) interface{}
+	// decode parses a page token value back into a query argument.
+	decode func(json.RawMessage) (interface{}, error)
+}
+
+// eventOrderFields maps each sort_by value onto how it is sorted. Only values
+// present here may be used to order events.
+var eventOrderFields = map[string]eventOrderField{
+	"advertised_start_time": {
+		// Start times are stored as RFC3339 text which may carry differing
+		// offsets, so they are compared as instants rather than strings.
+		field:  "advertised_start_time",
+		expr:   "julianday(advertised_start_time)",
+		param:  "julianday(?)",
+		key:    func(e *sports.Event) interface{} { return e.AdvertisedStartTime.AsTime().Format(time.RFC3339Nano) },
+		decode: decodeTimeKey,
+	},
+	"meeting_id": {
+		field:  "meeting_id",
+		expr:   "meeting_id",
+		param:  "?",
+		key:    func(e *sports.Event) interface{} { return e.MeetingId },
+		decode: decodeIntKey,
+	},
+	"number": {
+		field:  "number",
+		expr:   "number",
+		param:  "?",
+		key:    func(e *sports.Event) interface{} { return e.Number },
+		decode: decodeIntKey,
+	},
+	"name": {
+		field:  "name",
+		expr:   "name",
+		param:  "?",
+		key:    func(e *sports.Event) interface{} { return e.Name },
+		decode: decodeStringKey,
+	},
+	"level": {
+		field:  "level",
+		expr:   "level",
+		param:  "?",
+		key:    func(e *sports.Event) interface{} { return e.Level },
+		decode: decodeStringKey,
+	},
+	"id": {
+		field:  "id",
+		expr:   "id",
+		param:  "?",
+		key:    func(e *sports.Event) interface{} { return e.Id },
+		decode: decodeIntKey,
+	},
+}
+
+// orderTerm is a single resolved key of an ORDER BY clause.
+type orderTerm struct {
+	eventOrderField
+	desc bool
+}
+
+func (t orderTerm) direction() string {
+	if t.desc {
+		return "DESC"
+	}
+
+	return "ASC"
+}
+
+// comparator returns the operator matching rows sorting after a key value.
+func (t orderTerm) comparator() string {
+	if t.desc {
+		return "<"
+	}
+
+	return ">"
+}
+
+// eventOrderTerms resolves a filter's sort_by and order against the
+// allow-list, appending id as a tie-breaker so ordering is stable.
+func eventOrderTerms(filter *sports.ListEventsRequestFilter) ([]orderTerm, error) {
+	// If no sort_by field is supplied, order will default to by
+	// advertised_start_time.
+	sortBy := strings.ToLower(filter.GetSortBy())
+	if sortBy == "" {
+		sortBy = "advertised_start_time"
+	}
+
+	field, ok := eventOrderFields[sortBy]
+	if !ok {
+		return nil, invalidField("filter.sort_by", "cannot order events by %q", filter.GetSortBy())
+	}
+
+	/*	The order is given in natural language rather than as a boolean, to
+		make it easier for users. The default order, if not specified, is
+		descending, making the most recent events appear first.
+	*/
+	desc := true
+	if s := strings.ToUpper(filter.GetOrder()); s == "ASC" || s == "ASCENDING" {
+		desc = false
+	}
+
+	terms := []orderTerm{{eventOrderField: field, desc: desc}}
+	if sortBy != "id" {
+		terms = append(terms, orderTerm{eventOrderField: eventOrderFields["id"]})
+	}
+
+	return terms, nil
+}
+
+// orderClause renders terms as an ORDER BY clause.
+func orderClause(terms []orderTerm) string {
+	var keys []string
+	for _, term := range terms {
+		keys = append(keys, term.expr+" "+term.direction())
+	}
+
+	return " ORDER BY " + strings.Join(keys, ", ")
+}
+
+// orderKeys extracts the values of each ordering term from an event, for use
+// as the position recorded in a page token.
+func orderKeys(terms []orderTerm, event *sports.Event) []interface{} {
+	var keys []interface{}
+	for _, term := range terms {
+		keys = append(keys, term.key(event))
+	}
+
+	return keys
+}
+
+// keysetClause builds a WHERE clause matching only rows that sort strictly
+// after the position recorded by keys. For terms (a, id) this expands to
+// a > ? OR (a = ? AND id > ?), with operators flipped for descending terms.
+func keysetClause(terms []orderTerm, keys []json.RawMessage) (string, []interface{}, error) {
+	values, err := decodeKeys(terms, keys)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var (
+		branches []string
+		args     []interface{}
+	)
+
+	for i, term := range terms {
+		var conds []string
+		for j := 0; j < i; j++ {
+			conds = append(conds, terms[j].expr+" = "+terms[j].param)
+			args = append(args, values[j])
+		}
+		conds = append(conds, term.expr+" "+term.comparator()+" "+term.param)
+		args = append(args, values[i])
+
+		branches = append(branches, "("+strings.Join(conds, " AND ")+")")
+	}
+
+	return "(" + strings.Join(branches, " OR ") + ")", args, nil
+}
+
+// decodeKeys decodes the values of each ordering term recorded by keys.
+func decodeKeys(terms []orderTerm, keys []json.RawMessage) ([]interface{}, error) {
+	if len(keys) != len(terms) {
+		return nil, invalidPageToken(page.ErrOrderMismatch)
+	}
+
+	values := make([]interface{}, len(keys))
+	for i, term := range terms {
+		value, err := term.decode(keys[i])
+		if err != nil {
+			return nil, invalidPageToken(page.ErrMalformedToken)
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+func decodeIntKey(raw json.RawMessage) (interface{}, error) {
+	var v int64
+	err := json.Unmarshal(raw, &v)
+	return v, err
+}
+
+func decodeStringKey(raw json.RawMessage) (interface{}, error) {
+	var v string
+	err := json.Unmarshal(raw, &v)
+	return v, err
+}
+
+func decodeTimeKey(raw json.RawMessage) (interface{}, error) {
+	var v string
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+
+	if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/proto"
+
+	"git.neds.sh/matty/entain/shared/page"
+)
+
+// pageSize validates a requested page size, applying the default and max.
+func pageSize(requested int32) (int, error) {
+	size, err := page.Size(requested)
+	if err != nil {
+		return 0, invalidField("page_size", "%v", err)
+	}
+
+	return size, nil
+}
+
+// pageChecksum fingerprints the parts of a list request that must not change
+// between pages.
+func pageChecksum(filter proto.Message, terms []orderTerm) uint32 {
+	var order []string
+	for _, term := range terms {
+		order = append(order, term.expr+" "+term.direction())
+	}
+
+	return page.Checksum(filter, order)
+}
+
+// invalidPageToken returns the FieldError for a page token rejected by the
+// page package with err.
+func invalidPageToken(err error) error {
+	return invalidField("page_token", "%v", err)
+}
+
+// decodePageToken decodes the keys recorded by a page token, which must have
+// been issued for a request with checksum.
+func decodePageToken(s string, checksum uint32) ([]json.RawMessage, error) {
+	keys, err := page.DecodeToken(s, checksum)
+	if err != nil {
+		return nil, invalidPageToken(err)
+	}
+
+	return keys, nil
+}
@@ -1,7 +1,12 @@
 package db
 
 const (
-	eventsList = "list"
+	eventsList          = "list"
+	eventsSearch        = "search"
+	eventsSelect        = "select"
+	eventsHistory       = "history"
+	eventsHistoryInsert = "historyInsert"
+	eventsArchive       = "archive"
 )
 
 func getEventQueries() map[string]string {
@@ -15,8 +20,50 @@ func getEventQueries() map[string]string {
 				visible, 
 				advertised_start_time,
 				level,
-				sold_out
+				sold_out,
+				version,
+				archived_time
 			FROM events
 		`,
+		eventsSelect: `
+			SELECT %s FROM events
+		`,
+		eventsArchive: `
+			UPDATE events SET archived_time = ?, version = version + 1 WHERE id = ?
+		`,
+		eventsHistory: `
+			SELECT id, event_id, action, actor, reason, changed_time, version, changes
+			FROM event_history
+			WHERE event_id = ? AND id > ?
+			ORDER BY id
+			LIMIT ?
+		`,
+		eventsHistoryInsert: `
+			INSERT INTO event_history (event_id, action, actor, reason, changed_time, version, changes) VALUES (?, ?, ?, ?, ?, ?, ?)
+		`,
+		eventsSearch: `
+			SELECT
+				id,
+				meeting_id,
+				name,
+				number,
+				visible,
+				advertised_start_time,
+				level,
+				sold_out,
+				version,
+				archived_time,
+				snippet,
+				score
+			FROM (
+				SELECT
+					rowid AS match_id,
+					snippet(events_fts, 0, '<mark>', '</mark>', '…', ?) AS snippet,
+					bm25(events_fts) AS score
+				FROM events_fts
+				WHERE events_fts MATCH ?
+			)
+			JOIN events ON events.id = match_id
+		`,
 	}
 }
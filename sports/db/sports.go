@@ -2,14 +2,20 @@ package db
 
 import (
 	"database/sql"
+	"fmt"
+	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	_ "github.com/mattn/go-sqlite3"
+	"google.golang.org/genproto/protobuf/field_mask"
 
 	"sports/proto/sports"
+
+	"git.neds.sh/matty/entain/shared/page"
+	"git.neds.sh/matty/entain/shared/search"
 )
 
 // EventsRepo provides repository access to events.
@@ -17,153 +23,379 @@ type EventsRepo interface {
 	// Init will initialise our events repository.
 	Init() error
 
-	// List will return a list of events.
-	List(filter *sports.ListEventsRequestFilter) ([]*sports.Event, error)
+	// List will return a page of events, along with a token for the next
+	// page, or an empty token if there are no further events. Only the
+	// columns needed for the fields named by the request's read mask, and to
+	// page on, are read.
+	List(in *sports.ListEventsRequest) ([]*sports.Event, string, error)
+
+	// Search will return a page of the events whose names match a query,
+	// most relevant first, along with a token for the next page, or an empty
+	// token if there are no further events.
+	Search(in *sports.SearchEventsRequest) ([]*sports.EventSearchResult, string, error)
+
+	// History will return a page of the changes made to an event, oldest
+	// first, along with a token for the next page, or an empty token if
+	// there are no further changes. Events are only written by Seed and
+	// Archive, which record their changes.
+	History(in *sports.ListEventHistoryRequest) ([]*sports.EventChange, string, error)
+
+	// Archive will archive up to limit events advertised to start more than
+	// olderThan ago, oldest first, returning how many it archived. Archived
+	// events are kept, but left out of List and Search unless asked for.
+	Archive(olderThan time.Duration, limit int, audit Audit) (int64, error)
+}
+
+// eventReadColumns maps the fields of an event that may be read onto the
+// columns they are read from.
+var eventReadColumns = map[string][]string{
+	"id":                    {"id"},
+	"meeting_id":            {"meeting_id"},
+	"name":                  {"name"},
+	"number":                {"number"},
+	"visible":               {"visible"},
+	"advertised_start_time": {"advertised_start_time"},
+	"status":                {"advertised_start_time"},
+	"level":                 {"level"},
+	"sold_out":              {"sold_out"},
+	"etag":                  {"version"},
+	"archived_time":         {"archived_time"},
 }
 
+// eventColumns are all of the columns an event is read from, in the order
+// they are selected.
+var eventColumns = []string{"id", "meeting_id", "name", "number", "visible", "advertised_start_time", "level", "sold_out", "version", "archived_time"}
+
 type eventsRepo struct {
-	db   *sql.DB
-	init sync.Once
+	db    *sql.DB
+	clock Clock
 }
 
-// NewEventsRepo creates a new events repository.
-func NewEventsRepo(db *sql.DB) EventsRepo {
-	return &eventsRepo{db: db}
+// NewEventsRepo creates a new events repository, deriving event status from
+// clock.
+func NewEventsRepo(db *sql.DB, clock Clock) EventsRepo {
+	return &eventsRepo{db: db, clock: clock}
 }
 
-// Init prepares the events repository dummy data.
+// Init does nothing, as dummy events are only seeded by the seed command.
+// The events table is created by migrations.
 func (r *eventsRepo) Init() error {
-	var err error
+	return nil
+}
 
-	r.init.Do(func() {
-		// For test/example purposes, we seed the DB with some dummy events.
-		err = r.seed()
-	})
+func (r *eventsRepo) List(in *sports.ListEventsRequest) ([]*sports.Event, string, error) {
+	terms, err := eventOrderTerms(in.GetFilter())
+	if err != nil {
+		return nil, "", err
+	}
 
-	return err
-}
+	size, err := pageSize(in.GetPageSize())
+	if err != nil {
+		return nil, "", err
+	}
 
-func (r *eventsRepo) List(filter *sports.ListEventsRequestFilter) ([]*sports.Event, error) {
-	var (
-		err   error
-		query string
-		args  []interface{}
-	)
+	now, err := r.now(in.GetAsOf())
+	if err != nil {
+		return nil, "", err
+	}
 
-	query = getEventQueries()[eventsList]
+	clauses, args := r.applyFilter(in.GetFilter())
 
-	query, args = r.applyFilter(query, filter)
+	columns, err := readColumns(in.GetReadMask(), terms)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Resume after the last event of the previous page, if there was one.
+	checksum := pageChecksum(in.GetFilter(), terms)
+	if in.GetPageToken() != "" {
+		keys, err := decodePageToken(in.GetPageToken(), checksum)
+		if err != nil {
+			return nil, "", err
+		}
+
+		clause, keyArgs, err := keysetClause(terms, keys)
+		if err != nil {
+			return nil, "", err
+		}
+
+		clauses = append(clauses, clause)
+		args = append(args, keyArgs...)
+	}
+
+	query := fmt.Sprintf(getEventQueries()[eventsSelect], strings.Join(columns, ", "))
+	if len(clauses) != 0 {
+		query += " WHERE " + strings.Join(clauses, " AND ")
+	}
+
+	// Fetch one more event than requested to learn whether a next page exists.
+	query += orderClause(terms) + " LIMIT ?"
+	args = append(args, size+1)
 
 	rows, err := r.db.Query(query, args...)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	events, err := scanEvents(rows, now, columns)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextPageToken string
+	if len(events) > size {
+		events = events[:size]
+
+		nextPageToken, err = page.EncodeToken(checksum, orderKeys(terms, events[size-1]))
+		if err != nil {
+			return nil, "", err
+		}
 	}
 
-	return r.scanEvents(rows)
+	return events, nextPageToken, nil
 }
 
-func (r *eventsRepo) applyFilter(query string, filter *sports.ListEventsRequestFilter) (string, []interface{}) {
-	illegalCharFlag := false
+func (r *eventsRepo) Search(in *sports.SearchEventsRequest) ([]*sports.EventSearchResult, string, error) {
+	match := search.MatchQuery(in.GetQuery())
+	if match == "" {
+		return nil, "", invalidField("query", "must contain a word to search for")
+	}
+
+	size, err := pageSize(in.GetPageSize())
+	if err != nil {
+		return nil, "", err
+	}
+
+	now, err := r.now(in.GetAsOf())
+	if err != nil {
+		return nil, "", err
+	}
+
+	clauses, filterArgs := r.applyFilter(in.GetFilter())
+	args := append([]interface{}{search.SnippetTokens, match}, filterArgs...)
+
+	// Results are always ordered by score then ID, so a page token records
+	// the score and ID of the last result returned. The query is part of the
+	// checksum, as a token is only meaningful against the same matches.
+	checksum := pageChecksum(&sports.SearchEventsRequest{Query: in.GetQuery(), Filter: in.GetFilter()}, nil)
+	if in.GetPageToken() != "" {
+		keys, err := decodePageToken(in.GetPageToken(), checksum)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var (
+			score float64
+			id    int64
+		)
+		if err := page.DecodeKeys(keys, &score, &id); err != nil {
+			return nil, "", invalidPageToken(err)
+		}
+
+		clauses = append(clauses, "(score > ? OR (score = ? AND id > ?))")
+		args = append(args, score, score, id)
+	}
+
+	query := getEventQueries()[eventsSearch]
+	if len(clauses) != 0 {
+		query += " WHERE " + strings.Join(clauses, " AND ")
+	}
+
+	// bm25 scores are lower the more relevant the match. Fetch one more
+	// result than requested to learn whether a next page exists.
+	query += " ORDER BY score ASC, id ASC LIMIT ?"
+	args = append(args, size+1)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
 	var (
-		clauses []string
-		args    []interface{}
+		results []*sports.EventSearchResult
+		scores  []float64
 	)
+	for rows.Next() {
+		var (
+			snippet string
+			score   float64
+		)
 
-	if filter == nil {
-		return query, args
+		event, err := scanEvent(rows, now, eventColumns, &snippet, &score)
+		if err != nil {
+			return nil, "", err
+		}
+
+		// Scores are negated for callers, so that higher is more relevant.
+		results = append(results, &sports.EventSearchResult{Event: event, Snippet: snippet, Score: -score})
+		scores = append(scores, score)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
 	}
 
-	if len(filter.MeetingIds) > 0 {
-		clauses = append(clauses, "meeting_id IN ("+strings.Repeat("?,", len(filter.MeetingIds)-1)+"?)")
+	var nextPageToken string
+	if len(results) > size {
+		results = results[:size]
 
-		for _, meetingID := range filter.MeetingIds {
-			args = append(args, meetingID)
+		nextPageToken, err = page.EncodeToken(checksum, []interface{}{scores[size-1], results[size-1].Event.Id})
+		if err != nil {
+			return nil, "", err
 		}
 	}
 
-	// Check if visible only has been supplied as true. If false,
-	// or omitted, all events will be returned.
-	if filter.VisibleOnly {
-		clauses = append(clauses, "visible = ?")
-		args = append(args, true)
+	return results, nextPageToken, nil
+}
+
+func (r *eventsRepo) Archive(olderThan time.Duration, limit int, audit Audit) (int64, error) {
+	if err := validateArchive(olderThan, limit); err != nil {
+		return 0, err
 	}
 
-	if len(clauses) != 0 {
-		query += " WHERE " + strings.Join(clauses, " AND ")
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Events have no results, so are archived once they started long ago.
+	now := r.clock.Now()
+	rows, err := tx.Query(
+		getEventQueries()[eventsList]+` WHERE archived_time IS NULL AND julianday(advertised_start_time) < julianday(?) ORDER BY julianday(advertised_start_time), id LIMIT ?`,
+		now.Add(-olderThan).UTC().Format(time.RFC3339Nano),
+		limit,
+	)
+	if err != nil {
+		return 0, err
+	}
+	events, err := scanEvents(rows, now, eventColumns)
+	rows.Close()
+	if err != nil {
+		return 0, err
 	}
 
-	query += " ORDER BY "
+	for _, event := range events {
+		if _, err := tx.Exec(getEventQueries()[eventsArchive], now.UTC().Format(time.RFC3339Nano), event.Id); err != nil {
+			return 0, err
+		}
 
-	orderByStr := "advertised_start_time"
+		archived, err := getEvent(tx, event.Id, now)
+		if err != nil {
+			return 0, err
+		}
 
-	// Function to check if an illegal character is entered by the user.
-	strContainsIllegalChar := func(str string) bool {
-		for i := 0; i < len(str); i++ {
-			if str[i] == byte(';') {
-				return true
-			}
+		if err := recordEventChange(tx, event, archived, audit, now); err != nil {
+			return 0, err
 		}
-		return false
 	}
 
-	/*	If the user supplied string can is a key that can be sorted by; if
-		no sort_by field is supplied, order will default to by advertised_start_time.
-		In the event a value that cannot be sorted by is supplied, the code already
-		return an error code 2 for unknown strings, and code 3 for unsupported data
-		types.
-	*/
-	if s := strings.ToLower(filter.SortBy); len(s) > 0 {
-		query += s
-	} else if strContainsIllegalChar(s) {
-		illegalCharFlag = true
-		query += orderByStr
-	} else {
-		query += orderByStr
+	if err := tx.Commit(); err != nil {
+		return 0, err
 	}
 
-	/*	Since this is a binary option of ascending or descending order
-		the code could be made more efficient by making sort_by a boolean
-		rather than a string. However, it will make it easier for users if
-		they can specify in natural language rather than 0 or 1. The default
-		order, if not specified will be descending, making the most recent
-		races appear first.
-	*/
+	return int64(len(events)), nil
+}
 
-	if s := strings.ToUpper(filter.Order); s == "ASC" || s == "ASCENDING" {
-		query += " ASC"
-	} else if strContainsIllegalChar(s) {
-		illegalCharFlag = true
-		query += " DESC"
-	} else {
-		query += " DESC"
+// getEvent fetches a single event within a transaction, returning
+// ErrNotFound if there is no event with the ID.
+func getEvent(tx *sql.Tx, eventId int64, now time.Time) (*sports.Event, error) {
+	rows, err := tx.Query(getEventQueries()[eventsList]+" WHERE id = ?", eventId)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
+
+	events, err := scanEvents(rows, now, eventColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%w: event %d", ErrNotFound, eventId)
+	}
+
+	return events[0], nil
+}
+
+// validateArchive checks the age and batch size events are archived with.
+func validateArchive(olderThan time.Duration, limit int) error {
+	switch {
+	case olderThan <= 0:
+		return fmt.Errorf("%w: archive age must be positive", ErrInvalidArgument)
+	case limit < 1:
+		return fmt.Errorf("%w: archive limit must be positive", ErrInvalidArgument)
+	}
+
+	return nil
+}
+
+func (r *eventsRepo) now(asOf *timestamp.Timestamp) (time.Time, error) {
+	return statusInstant(r.clock, asOf)
+}
 
-	/* 	Not really possible, from my testing, to get extraneous SQL commands to
-	execute on the server, but given the order_by part allows user input with errors
-	handled higher up it might potentially be possible for a malicious user to sneak
-	a semi-colon in and run a distinct SQL command, especially if the order of code
-	is moved around throughout development. Probably not necessary, but worth noting
-	something to do with security, especially since the API uses POST instead of GET.
-	*/
-	if illegalCharFlag {
-		// Do something  useful here, like flag user.
-		{
+// statusInstant returns the instant event status is derived at, being asOf
+// if given or else the time of clock. It is truncated to the millisecond
+// precision SQLite compares times at.
+func statusInstant(clock Clock, asOf *timestamp.Timestamp) (time.Time, error) {
+	if asOf == nil {
+		return clock.Now().Truncate(time.Millisecond), nil
+	}
+
+	t, err := ptypes.Timestamp(asOf)
+	if err != nil {
+		return time.Time{}, invalidField("as_of", "%s", err)
+	}
+
+	return t.Truncate(time.Millisecond), nil
+}
+
+// applyFilter converts a filter into SQL WHERE conditions and their args.
+func (r *eventsRepo) applyFilter(filter *sports.ListEventsRequestFilter) ([]string, []interface{}) {
+	var (
+		clauses []string
+		args    []interface{}
+	)
+
+	// Archived events are left out unless asked for, which also lets the
+	// partial index on the start times of events not archived be used.
+	if !filter.GetIncludeArchived() {
+		clauses = append(clauses, "archived_time IS NULL")
+	}
+
+	if filter == nil {
+		return clauses, args
+	}
+
+	if len(filter.MeetingIds) > 0 {
+		clauses = append(clauses, "meeting_id IN ("+strings.Repeat("?,", len(filter.MeetingIds)-1)+"?)")
+
+		for _, meetingID := range filter.MeetingIds {
+			args = append(args, meetingID)
 		}
 	}
 
-	return query, args
+	// Check if visible only has been supplied as true. If false,
+	// or omitted, all events will be returned.
+	if filter.VisibleOnly {
+		clauses = append(clauses, "visible = ?")
+		args = append(args, true)
+	}
+
+	return clauses, args
 }
 
-func (m *eventsRepo) scanEvents(
+func scanEvents(
 	rows *sql.Rows,
+	now time.Time,
+	columns []string,
 ) ([]*sports.Event, error) {
 	var events []*sports.Event
 
 	for rows.Next() {
-		var event sports.Event
-		var advertisedStart time.Time
-
-		if err := rows.Scan(&event.Id, &event.MeetingId, &event.Name, &event.Number, &event.Visible, &advertisedStart, &event.Level, &event.SoldOut); err != nil {
+		event, err := scanEvent(rows, now, columns)
+		if err != nil {
 			if err == sql.ErrNoRows {
 				return nil, nil
 			}
@@ -171,6 +403,54 @@ func (m *eventsRepo) scanEvents(
 			return nil, err
 		}
 
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// scanEvent scans the event at the current row, whose columns are those
+// given, in the order of eventColumns, followed by any into extra. Fields not
+// read from any of the columns are left unset.
+func scanEvent(rows *sql.Rows, now time.Time, columns []string, extra ...interface{}) (*sports.Event, error) {
+	var event sports.Event
+	var (
+		advertisedStart time.Time
+		version         int64
+		archived        sql.NullTime
+	)
+
+	dest := make([]interface{}, 0, len(columns)+len(extra))
+	for _, column := range columns {
+		switch column {
+		case "id":
+			dest = append(dest, &event.Id)
+		case "meeting_id":
+			dest = append(dest, &event.MeetingId)
+		case "name":
+			dest = append(dest, &event.Name)
+		case "number":
+			dest = append(dest, &event.Number)
+		case "visible":
+			dest = append(dest, &event.Visible)
+		case "advertised_start_time":
+			dest = append(dest, &advertisedStart)
+		case "level":
+			dest = append(dest, &event.Level)
+		case "sold_out":
+			dest = append(dest, &event.SoldOut)
+		case "version":
+			dest = append(dest, &version)
+		case "archived_time":
+			dest = append(dest, &archived)
+		}
+	}
+
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	if !advertisedStart.IsZero() {
 		ts, err := ptypes.TimestampProto(advertisedStart)
 		if err != nil {
 			return nil, err
@@ -178,18 +458,65 @@ func (m *eventsRepo) scanEvents(
 
 		event.AdvertisedStartTime = ts
 
-		/* 	Compare advertised start time of event to current timestamp.
-		If current time is after advertised start time, event is closed,
-		otherwise it is still open.
+		/* 	Compare advertised start time of event to now. If now is after
+		advertised start time, event is closed, otherwise it is still open.
 		*/
-		if time.Now().After(time.Unix(ts.Seconds, int64(ts.Nanos))) {
+		if now.After(time.Unix(ts.Seconds, int64(ts.Nanos))) {
 			event.Status = "CLOSED"
 		} else {
 			event.Status = "OPEN"
 		}
+	}
+
+	// The etag is the version, which is opaque to callers.
+	if version != 0 {
+		event.Etag = strconv.FormatInt(version, 10)
+	}
+
+	if archived.Valid {
+		ts, err := ptypes.TimestampProto(archived.Time)
+		if err != nil {
+			return nil, err
+		}
+
+		event.ArchivedTime = ts
+	}
+
+	return &event, nil
+}
 
-		events = append(events, &event)
+// readColumns resolves a read mask to the columns to select, being those the
+// named fields are read from along with those ordered on by terms, which
+// page tokens are made from. Every column is selected when there is no mask.
+func readColumns(mask *field_mask.FieldMask, terms []orderTerm) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		return eventColumns, nil
+	}
+
+	needed := make(map[string]bool)
+	for _, path := range mask.GetPaths() {
+		columns, ok := eventReadColumns[path]
+		if !ok {
+			return nil, invalidField("read_mask", "unknown field %q", path)
+		}
+
+		for _, column := range columns {
+			needed[column] = true
+		}
+	}
+
+	for _, term := range terms {
+		for _, column := range eventReadColumns[term.field] {
+			needed[column] = true
+		}
+	}
+
+	var columns []string
+	for _, column := range eventColumns {
+		if needed[column] {
+			columns = append(columns, column)
+		}
 	}
 
-	return events, nil
+	return columns, nil
 }
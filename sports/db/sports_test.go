@@ -0,0 +1,125 @@
+package db_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"sports/db"
+	"sports/db/dbtest"
+)
+
+func TestSQLiteEventsRepo(t *testing.T) {
+	// Without FTS5, the suite fails once here rather than in every test.
+	if _, err := migratedDB(t); err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+
+	dbtest.TestEventsRepo(t, func(t *testing.T, clock db.Clock, fixture db.EventsFixture) db.EventsRepo {
+		sportingDB, err := migratedDB(t)
+		if err != nil {
+			t.Fatalf("migrating: %v", err)
+		}
+
+		if err := insertEventsFixture(sportingDB, fixture); err != nil {
+			t.Fatalf("inserting fixture: %v", err)
+		}
+
+		return db.NewEventsRepo(sportingDB, clock)
+	})
+}
+
+func TestMemoryEventsRepo(t *testing.T) {
+	dbtest.TestEventsRepo(t, func(t *testing.T, clock db.Clock, fixture db.EventsFixture) db.EventsRepo {
+		return db.NewMemoryEventsRepo(clock, fixture)
+	})
+}
+
+// migratedDB opens an empty database, closed at the end of the test, and
+// migrates it up to date. It fails the test if SQLite lacks FTS5.
+func migratedDB(t *testing.T) (*sql.DB, error) {
+	sportingDB := emptyDB(t)
+	_, err := migrate(t, sportingDB)
+	return sportingDB, err
+}
+
+// emptyDB opens an empty database, closed at the end of the test.
+func emptyDB(t *testing.T) *sql.DB {
+	sportingDB, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "events.db"))
+	if err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+	t.Cleanup(func() { sportingDB.Close() })
+
+	return sportingDB
+}
+
+// migrate migrates a database up to date, returning the migrations applied.
+// It fails the test if SQLite lacks FTS5.
+func migrate(t *testing.T, sportingDB *sql.DB) ([]db.MigrationStatus, error) {
+	// Search needs FTS5, without which the SQLite repository cannot be
+	// tested at all.
+	applied, err := db.Migrate(sportingDB)
+	if err != nil && strings.Contains(err.Error(), "no such module: fts5") {
+		t.Fatalf("SQLite is built without FTS5; run the tests with -tags sqlite_fts5, as make test does: %v", err)
+	}
+	return applied, err
+}
+
+// insertEventsFixture stores a fixture as rows, as the in-memory repository
+// holds it.
+func insertEventsFixture(sportingDB *sql.DB, fixture db.EventsFixture) error {
+	for _, event := range fixture.Events {
+		version := int64(1)
+		if event.Etag != "" {
+			var err error
+			if version, err = strconv.ParseInt(event.Etag, 10, 64); err != nil {
+				return err
+			}
+		}
+
+		var archived interface{}
+		if event.ArchivedTime != nil {
+			archived = event.ArchivedTime.AsTime().UTC().Format(time.RFC3339Nano)
+		}
+
+		_, err := sportingDB.Exec(`INSERT INTO events (id, meeting_id, name, number, visible, advertised_start_time, level, sold_out, version, archived_time) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			event.Id, event.MeetingId, event.Name, event.Number, event.Visible,
+			event.AdvertisedStartTime.AsTime().UTC().Format(time.RFC3339Nano), event.Level, event.SoldOut, version, archived)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, change := range fixture.History {
+		type fieldChange struct {
+			Field string `json:"field"`
+			Old   string `json:"old,omitempty"`
+			New   string `json:"new,omitempty"`
+		}
+
+		var fields []fieldChange
+		for _, field := range change.Fields {
+			fields = append(fields, fieldChange{Field: field.Field, Old: field.OldValue, New: field.NewValue})
+		}
+		changes, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+
+		_, err = sportingDB.Exec(`INSERT INTO event_history (id, event_id, action, actor, reason, changed_time, version, changes) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			change.Id, change.EventId, change.Action.String(), change.Actor, change.Reason,
+			change.ChangeTime.AsTime().UTC().Format(time.RFC3339Nano), change.Etag, string(changes))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,26 +2,58 @@ package main
 
 import (
 	"database/sql"
+	"errors"
 	"flag"
 	"log"
 	"net"
+	"os/user"
 
 	"sports/db"
 	"sports/proto/sports"
 	"sports/service"
 
+	"git.neds.sh/matty/entain/shared/retention"
 	"google.golang.org/grpc"
 )
 
 var (
 	grpcEndpoint = flag.String("grpc-endpoint", "localhost:9999", "gRPC server endpoint")
+	mode         = flag.String("mode", developmentMode, "mode to run in, development or production")
 )
 
+// The modes the service may be run in. Dummy data is only seeded in
+// development mode, unless forced.
+const (
+	developmentMode = "development"
+	productionMode  = "production"
+)
+
+// eventsDBPath is the path of the events database.
+const eventsDBPath = "./db/events.db"
+
 func main() {
 	flag.Parse()
 
-	if err := run(); err != nil {
-		log.Fatalf("failed running grpc server: %s\n", err)
+	if *mode != developmentMode && *mode != productionMode {
+		log.Fatalf("unknown mode %q\n", *mode)
+	}
+
+	// With no command, the gRPC server is run.
+	switch flag.Arg(0) {
+	case "":
+		if err := run(); err != nil {
+			log.Fatalf("failed running grpc server: %s\n", err)
+		}
+	case "migrate":
+		if err := runMigrate(flag.Args()[1:]); err != nil {
+			log.Fatalf("failed migrating events database: %s\n", err)
+		}
+	case "seed":
+		if err := runSeed(flag.Args()[1:]); err != nil {
+			log.Fatalf("failed seeding events database: %s\n", err)
+		}
+	default:
+		log.Fatalf("unknown command %q\n", flag.Arg(0))
 	}
 }
 
@@ -31,16 +63,25 @@ func run() error {
 		return err
 	}
 
-	sportingDB, err := sql.Open("sqlite3", "./db/events.db")
+	sportingDB, err := openDB()
 	if err != nil {
 		return err
 	}
 
-	eventsRepo := db.NewEventsRepo(sportingDB)
+	eventsRepo := db.NewEventsRepo(sportingDB, db.SystemClock)
 	if err := eventsRepo.Init(); err != nil {
 		return err
 	}
 
+	switch {
+	case *retentionAge < 0:
+		return errors.New("-retention-age must not be negative")
+	case *retentionAge > 0 && *retentionInterval <= 0:
+		return errors.New("-retention-interval must be positive")
+	case *retentionAge > 0:
+		go retention.Run(archiveEvents(eventsRepo, *retentionAge), *retentionInterval, "events started more than "+retentionAge.String()+" ago")
+	}
+
 	grpcServer := grpc.NewServer()
 
 	sports.RegisterEventsServer(
@@ -58,3 +99,34 @@ func run() error {
 
 	return nil
 }
+
+// openDB opens the events database, migrating its schema up to date before
+// any repository uses it.
+func openDB() (*sql.DB, error) {
+	sportingDB, err := sql.Open("sqlite3", eventsDBPath)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := db.Migrate(sportingDB)
+	for _, m := range applied {
+		log.Printf("applied migration %d %s\n", m.Version, m.Name)
+	}
+	if err != nil {
+		sportingDB.Close()
+		return nil, err
+	}
+
+	return sportingDB, nil
+}
+
+// currentUser names the user running a command, who the changes it makes are
+// recorded as made by unless it is told otherwise.
+func currentUser() string {
+	u, err := user.Current()
+	if err != nil || u.Username == "" {
+		return "anonymous"
+	}
+
+	return u.Username
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"flag"
+	"time"
+
+	"sports/db"
+
+	"git.neds.sh/matty/entain/shared/retention"
+)
+
+var (
+	retentionAge      = flag.Duration("retention-age", 90*24*time.Hour, "how long after its start an event is archived, or 0 to never archive events")
+	retentionInterval = flag.Duration("retention-interval", time.Hour, "how often past events are checked for archiving")
+)
+
+// archiveEvents archives events that started more than age ago.
+func archiveEvents(eventsRepo db.EventsRepo, age time.Duration) retention.Archiver {
+	audit := db.Audit{Actor: "retention", Reason: "started more than " + age.String() + " ago"}
+
+	return func(limit int) (int, error) {
+		n, err := eventsRepo.Archive(age, limit, audit)
+		return int(n), err
+	}
+}
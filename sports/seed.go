@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"sports/db"
+)
+
+// runSeed fills the events database with generated dummy events. The same
+// flags always generate the same data, so that environments seeded alike
+// hold identical datasets.
+func runSeed(args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	seed := flags.Int64("seed", 1, "seed of the random data generated")
+	meetings := flags.Int("meetings", 10, "number of meetings, each of a single sport")
+	eventsPerMeeting := flags.Int("events-per-meeting", 10, "number of events at each meeting")
+	from := flags.String("from", "", "first day meetings are held on, as YYYY-MM-DD (default yesterday, UTC)")
+	days := flags.Int("days", 3, "number of days meetings are spread across")
+	batchSize := flags.Int("batch-size", 500, "most rows inserted per transaction")
+	reset := flags.Bool("reset", false, "delete all existing events first")
+	force := flags.Bool("force", false, "seed even in production mode")
+	actor := flags.String("actor", currentUser(), "who the events are recorded in event history as created and deleted by")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: sports seed [flags]")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return errors.New("expected no arguments")
+	}
+
+	if *mode == productionMode && !*force {
+		return errors.New("seeding is disabled in production mode; pass -force to seed anyway")
+	}
+
+	// Without a first day, the dataset is only the same for runs on the
+	// same day.
+	first := time.Now().UTC().AddDate(0, 0, -1)
+	if *from != "" {
+		var err error
+		if first, err = time.Parse("2006-01-02", *from); err != nil {
+			return fmt.Errorf("invalid -from: %w", err)
+		}
+	}
+
+	sportingDB, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer sportingDB.Close()
+
+	result, err := db.Seed(sportingDB, db.SeedOptions{
+		Seed:             *seed,
+		Meetings:         *meetings,
+		EventsPerMeeting: *eventsPerMeeting,
+		From:             first,
+		Days:             *days,
+		BatchSize:        *batchSize,
+		Reset:            *reset,
+		Audit:            db.Audit{Actor: *actor, Reason: fmt.Sprintf("seeded with seed %d from %s", *seed, first.Format("2006-01-02"))},
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("seeded %d events from %s with seed %d\n", result.Events, first.Format("2006-01-02"), *seed)
+
+	return nil
+}
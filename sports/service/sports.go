@@ -6,11 +6,19 @@ import (
 	"sports/proto/sports"
 
 	"sports/db"
+
+	"git.neds.sh/matty/entain/shared/readmask"
 )
 
 type Events interface {
 	// ListEvents will return a collection of events.
 	ListEvents(ctx context.Context, in *sports.ListEventsRequest) (*sports.ListEventsResponse, error)
+
+	// SearchEvents will return the events whose names match a query.
+	SearchEvents(ctx context.Context, in *sports.SearchEventsRequest) (*sports.SearchEventsResponse, error)
+
+	// ListEventHistory will return the changes made to an event.
+	ListEventHistory(ctx context.Context, in *sports.ListEventHistoryRequest) (*sports.ListEventHistoryResponse, error)
 }
 
 // eventsService implements the Events interface.
@@ -24,10 +32,33 @@ func NewEventsService(eventsRepo db.EventsRepo) Events {
 }
 
 func (s *eventsService) ListEvents(ctx context.Context, in *sports.ListEventsRequest) (*sports.ListEventsResponse, error) {
-	events, err := s.eventsRepo.List(in.Filter)
+	events, nextPageToken, err := s.eventsRepo.List(in)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// Fields only read to page on are not returned.
+	for _, event := range events {
+		readmask.Apply(event, in.ReadMask)
+	}
+
+	return &sports.ListEventsResponse{Events: events, NextPageToken: nextPageToken}, nil
+}
+
+func (s *eventsService) SearchEvents(ctx context.Context, in *sports.SearchEventsRequest) (*sports.SearchEventsResponse, error) {
+	results, nextPageToken, err := s.eventsRepo.Search(in)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &sports.SearchEventsResponse{Results: results, NextPageToken: nextPageToken}, nil
+}
+
+func (s *eventsService) ListEventHistory(ctx context.Context, in *sports.ListEventHistoryRequest) (*sports.ListEventHistoryResponse, error) {
+	changes, nextPageToken, err := s.eventsRepo.History(in)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
-	return &sports.ListEventsResponse{Events: events}, nil
+	return &sports.ListEventHistoryResponse{Changes: changes, NextPageToken: nextPageToken}, nil
 }
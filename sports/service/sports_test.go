@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"sports/db"
+	"sports/proto/sports"
+)
+
+func TestToStatusError(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"field", fmt.Errorf("listing: %w", &db.FieldError{Field: "filter.sort_by", Description: "unknown"}), codes.InvalidArgument},
+		{"invalid argument", fmt.Errorf("%w: bad", db.ErrInvalidArgument), codes.InvalidArgument},
+		{"not found", fmt.Errorf("%w: event 99", db.ErrNotFound), codes.NotFound},
+		{"failed precondition", fmt.Errorf("%w: table events is not empty", db.ErrFailedPrecondition), codes.FailedPrecondition},
+		{"canceled", fmt.Errorf("querying: %w", context.Canceled), codes.Canceled},
+		{"deadline exceeded", fmt.Errorf("querying: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		{"status", status.Error(codes.Unavailable, "unavailable"), codes.Unavailable},
+		{"unknown", errors.New("disk I/O error"), codes.Internal},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if code := status.Code(toStatusError(tc.err)); code != tc.want {
+				t.Errorf("code = %s, want %s", code, tc.want)
+			}
+		})
+	}
+
+	// Internal errors do not expose their cause.
+	if st := status.Convert(toStatusError(errors.New("disk I/O error"))); st.Message() != "internal error" {
+		t.Errorf("message = %q, want internal error", st.Message())
+	}
+}
+
+func TestEventsServiceErrors(t *testing.T) {
+	s := newMemoryService(t)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name  string
+		call  func() error
+		field string
+	}{
+		{"unknown sort", func() error {
+			_, err := s.ListEvents(ctx, &sports.ListEventsRequest{Filter: &sports.ListEventsRequestFilter{SortBy: "teams"}})
+			return err
+		}, "filter.sort_by"},
+		{"unknown read mask path", func() error {
+			_, err := s.ListEvents(ctx, &sports.ListEventsRequest{ReadMask: &field_mask.FieldMask{Paths: []string{"teams"}}})
+			return err
+		}, "read_mask"},
+		{"empty search", func() error {
+			_, err := s.SearchEvents(ctx, &sports.SearchEventsRequest{Query: " ?! "})
+			return err
+		}, "query"},
+		{"malformed history page token", func() error {
+			_, err := s.ListEventHistory(ctx, &sports.ListEventHistoryRequest{EventId: 1, PageToken: "!"})
+			return err
+		}, "page_token"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			checkFieldViolation(t, tc.call(), tc.field)
+		})
+	}
+}
+
+func TestEventsServiceReadMask(t *testing.T) {
+	s := newMemoryService(t)
+
+	resp, err := s.ListEvents(context.Background(), &sports.ListEventsRequest{
+		Filter:   &sports.ListEventsRequestFilter{SortBy: "number", Order: "asc"},
+		ReadMask: &field_mask.FieldMask{Paths: []string{"id", "name"}},
+	})
+	if err != nil {
+		t.Fatalf("ListEvents: %v", err)
+	}
+
+	// Fields only read to page on, such as the number, are not returned.
+	want := []*sports.Event{{Id: 1, Name: "Tennis Open Final"}, {Id: 2, Name: "Archery"}}
+	if len(resp.Events) != len(want) {
+		t.Fatalf("events = %v, want %v", resp.Events, want)
+	}
+	for i, event := range resp.Events {
+		if !proto.Equal(event, want[i]) {
+			t.Errorf("event %d = %v, want %v", i, event, want[i])
+		}
+	}
+}
+
+// newMemoryService creates a service over an in-memory repository holding
+// events 1 and 2, numbered 1 and 2, and starting an hour either side of now.
+func newMemoryService(t *testing.T) Events {
+	now := time.Date(2021, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	var events []*sports.Event
+	for _, event := range []struct {
+		id     int64
+		name   string
+		number int64
+		start  time.Duration
+	}{
+		{1, "Tennis Open Final", 1, time.Hour},
+		{2, "Archery", 2, -time.Hour},
+	} {
+		start, err := ptypes.TimestampProto(now.Add(event.start))
+		if err != nil {
+			t.Fatalf("converting start: %v", err)
+		}
+		events = append(events, &sports.Event{Id: event.id, MeetingId: 1, Name: event.name, Number: event.number, Visible: true, AdvertisedStartTime: start, Level: "Professional"})
+	}
+
+	clock := db.ClockFunc(func() time.Time { return now })
+
+	return NewEventsService(db.NewMemoryEventsRepo(clock, db.EventsFixture{Events: events}))
+}
+
+// checkFieldViolation checks that err is an InvalidArgument status naming
+// field as at fault.
+func checkFieldViolation(t *testing.T, err error, field string) {
+	t.Helper()
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("err = %v, want InvalidArgument", err)
+	}
+
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.FieldViolations {
+				if violation.Field == field {
+					return
+				}
+			}
+		}
+	}
+	t.Errorf("details = %v, want a violation of %s", st.Details(), field)
+}